@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/alert"
+	"github.com/wosiu6/docky-go/internal/config"
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/exporter"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+	"github.com/wosiu6/docky-go/internal/filter"
+	"github.com/wosiu6/docky-go/internal/mask"
+	"github.com/wosiu6/docky-go/internal/plugin"
+	"github.com/wosiu6/docky-go/internal/traefik"
+	"github.com/wosiu6/docky-go/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// configPath finds --config in args before the flag set exists, since the
+// file provides the flag defaults. The default path may be missing; a path
+// given explicitly may not.
+func configPath(args []string) (path string, explicit bool) {
+	for i, a := range args {
+		if a == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(a, "-"), "=")
+		if !strings.HasPrefix(a, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value, true
+		}
+		if i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	if p := os.Getenv("DOCKY_CONFIG"); p != "" {
+		return p, true
+	}
+	return config.DefaultPath(), false
+}
+
+// loadConfig resolves the file and environment layers; flags are applied
+// on top by the caller's flag set.
+func loadConfig(args []string) (config.Config, string, error) {
+	path, explicit := configPath(args)
+	cfg, err := config.Load(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		cfg, err = config.Default(), nil
+	}
+	if err != nil {
+		return cfg, path, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, path, cfg.ApplyEnv(os.Getenv)
+}
+
+// commonFlags are the config overrides shared by every command that talks
+// to Docker.
+func commonFlags(fs *flag.FlagSet, cfg *config.Config, path string) {
+	fs.String("config", path, "config file (env DOCKY_CONFIG)")
+	fs.StringVar(&cfg.Docker.Host, "docker-host", cfg.Docker.Host, "Docker endpoint, e.g. unix:///var/run/docker.sock (env DOCKER_HOST)")
+	fs.DurationVar(&cfg.Docker.Timeout, "docker-timeout", cfg.Docker.Timeout, "timeout for Docker API requests")
+	fs.DurationVar(&cfg.Docker.PingTimeout, "ping-timeout", cfg.Docker.PingTimeout, "how long to wait for Docker at startup")
+	fs.IntVar(&cfg.Refresh.Concurrency, "concurrency", cfg.Refresh.Concurrency, "containers inspected in parallel (env DOCKY_CONCURRENCY)")
+	fs.StringVar(&cfg.Filter, "filter", cfg.Filter, "only show and export matching containers, e.g. 'status:running cpu>20' (env DOCKY_FILTER)")
+	fs.BoolVar(&cfg.Masking.Enabled, "mask", cfg.Masking.Enabled, "hide secret detail fields such as passwords (env DOCKY_MASK)")
+	fs.StringVar(&cfg.Traefik.API, "traefik-api", cfg.Traefik.API, "Traefik API URL for router status, e.g. http://localhost:8080 (env DOCKY_TRAEFIK_API)")
+}
+
+// applyConfig reports problems in the resolved config, exports the Docker
+// endpoint, which the transport and HostName read from DOCKER_HOST, and
+// applies the theme.
+func applyConfig(cfg config.Config) bool {
+	if problems := checkConfig(cfg); len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintln(os.Stderr, "config:", p)
+		}
+		return false
+	}
+	if cfg.Docker.Host != "" {
+		os.Setenv("DOCKER_HOST", cfg.Docker.Host)
+	}
+	ui.SetTheme(cfg.Theme)
+	return true
+}
+
+// checkConfig adds the checks owned by other packages to Config.Validate.
+func checkConfig(cfg config.Config) []string {
+	var problems []string
+	for _, err := range cfg.Validate() {
+		problems = append(problems, err.Error())
+	}
+	add := func(key string, err error) {
+		if err != nil {
+			problems = append(problems, key+": "+err.Error())
+		}
+	}
+	if !slices.Contains(ui.SortKeys(), cfg.Sort.Key) {
+		add("sort.key", fmt.Errorf("unknown sort key %q, expected one of %s", cfg.Sort.Key, strings.Join(ui.SortKeys(), ", ")))
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Themes)) {
+		t := cfg.Themes[name]
+		add("themes."+name, ui.RegisterTheme(name, t.Base, t.Colors, t.Types))
+	}
+	if !slices.Contains(ui.Themes(), cfg.Theme) {
+		add("theme", fmt.Errorf("unknown theme %q, expected one of %s", cfg.Theme, strings.Join(ui.Themes(), ", ")))
+	}
+	if err := ui.New(nil).SetKeyBindings(cfg.Keybindings); err != nil {
+		for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+			problems = append(problems, e.Error())
+		}
+	}
+	for i, spec := range cfg.Exporters.Push {
+		_, err := exporter.ParseSink(spec)
+		add(fmt.Sprintf("exporters.push[%d]", i), err)
+	}
+	add("alerts.rules", alert.Validate(alertRules(cfg)))
+	_, err := filter.Parse(cfg.Filter)
+	add("filter", err)
+	return problems
+}
+
+func fetcherConfig(cfg config.Config) fetcher.FetcherConfig {
+	fc := fetcher.FetcherConfig{Concurrency: cfg.Refresh.Concurrency, StormRestarts: cfg.Refresh.StormRestarts, StormWindow: cfg.Refresh.StormWindow, Strategies: plugin.Strategies(), Host: docker.ServiceHost()}
+	if cfg.Traefik.API != "" {
+		fc.Traefik = &traefik.Client{URL: cfg.Traefik.API, Client: &http.Client{Timeout: 2 * time.Second}}
+	}
+	return fc
+}
+
+func masker(cfg config.Config) *mask.Masker {
+	if !cfg.Masking.Enabled {
+		return nil
+	}
+	return mask.New(cfg.Masking.Patterns)
+}
+
+// alertRules is the built-in rules, unless disabled, with configured rules
+// replacing a built-in one of the same name.
+func alertRules(cfg config.Config) []alert.Rule {
+	var rules []alert.Rule
+	if cfg.Alerts.DefaultRules {
+		rules = alert.DefaultRules()
+	}
+	for _, r := range cfg.Alerts.Rules {
+		rule := alert.Rule{Name: r.Name, Expr: r.Expr, For: r.For, Severity: r.Severity}
+		if i := slices.IndexFunc(rules, func(d alert.Rule) bool { return d.Name == r.Name }); i >= 0 {
+			rules[i] = rule
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: docky-go config validate|path|print [--config FILE]")
+		return 2
+	}
+	sub := args[0]
+	fs := flag.NewFlagSet("config "+sub, flag.ExitOnError)
+	path, explicit := configPath(args[1:])
+	fs.String("config", path, "config file (env DOCKY_CONFIG)")
+	fs.Parse(args[1:])
+	switch sub {
+	case "path":
+		fmt.Println(path)
+		return 0
+	case "validate":
+		cfg, err := config.Load(path)
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			fmt.Printf("%s: not found, checking the defaults\n", path)
+			err = nil
+		}
+		problems := config.Problems(err)
+		if err != nil && problems == nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if err := cfg.ApplyEnv(os.Getenv); err != nil {
+			problems = append(problems, err.Error())
+		}
+		problems = append(problems, checkConfig(cfg)...)
+		for _, p := range problems {
+			fmt.Printf("%s: %s\n", path, p)
+		}
+		if len(problems) > 0 {
+			return 1
+		}
+		fmt.Printf("%s: ok\n", path)
+		return 0
+	case "print":
+		cfg, _, err := loadConfig(args[1:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "config:", err)
+			return 2
+		}
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(cfg.Redacted()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown config command %q, expected validate, path or print\n", sub)
+		return 2
+	}
+}
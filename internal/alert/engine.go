@@ -0,0 +1,213 @@
+package alert
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	ilog "github.com/wosiu6/docky-go/internal/log"
+)
+
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+type Event struct {
+	Status string
+	Alert  domain.Alert
+	EndsAt time.Time
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, ev Event) error
+}
+
+type key struct{ rule, id string }
+
+type state struct {
+	pendingSince time.Time
+	firing       bool
+	alert        domain.Alert
+}
+
+// Engine evaluates rules on every refresh. A rule only fires once its
+// condition has held for the rule's For duration, notifies once per
+// transition and sends a resolve notification when the condition clears or
+// the container disappears.
+type Engine struct {
+	rules     []compiledRule
+	notifiers []Notifier
+	logger    ilog.Logger
+	now       func() time.Time
+	timeout   time.Duration
+
+	mu     sync.Mutex
+	states map[key]*state
+	closed bool
+	queue  chan Event
+	wg     sync.WaitGroup
+
+	// base parents every notification and is cancelled when Close gives up
+	// on draining the queue; stopped closes when deliver returns.
+	base    context.Context
+	abort   context.CancelFunc
+	stopped chan struct{}
+}
+
+// queueSize bounds how many undelivered events are kept while a notifier is
+// slow; further events are dropped and logged.
+const queueSize = 256
+
+// drainTimeout is how long Close keeps delivering queued events.
+const drainTimeout = 5 * time.Second
+
+func NewEngine(rules []Rule, logger ilog.Logger, notifiers ...Notifier) (*Engine, error) {
+	e := &Engine{notifiers: notifiers, logger: logger, now: time.Now, timeout: 10 * time.Second, states: make(map[key]*state), queue: make(chan Event, queueSize), stopped: make(chan struct{})}
+	e.base, e.abort = context.WithCancel(context.Background())
+	for _, r := range rules {
+		cr, err := compile(r)
+		if err != nil {
+			return nil, err
+		}
+		e.rules = append(e.rules, cr)
+	}
+	go e.deliver()
+	return e, nil
+}
+
+// Evaluate checks every rule against every container, dispatches
+// notifications for state changes and returns the containers with their
+// firing alerts attached.
+func (e *Engine) Evaluate(ctx context.Context, containers []domain.Container) []domain.Container {
+	now := e.now()
+	e.mu.Lock()
+	seen := make(map[key]bool, len(e.states))
+	var events []Event
+	for i := range containers {
+		c := &containers[i]
+		c.Alerts = nil
+		for _, r := range e.rules {
+			k := key{r.Name, c.ID}
+			seen[k] = true
+			ok, observed := r.match(*c)
+			st := e.states[k]
+			if !ok {
+				if st != nil && st.firing {
+					events = append(events, Event{Status: StatusResolved, Alert: st.alert, EndsAt: now})
+				}
+				delete(e.states, k)
+				continue
+			}
+			if st == nil {
+				st = &state{pendingSince: now}
+				e.states[k] = st
+			}
+			st.alert = domain.Alert{Rule: r.Name, Severity: r.Severity, ContainerID: c.ID, Container: c.Name(), Value: observed, Since: st.pendingSince}
+			if !st.firing && now.Sub(st.pendingSince) >= r.For {
+				st.firing = true
+				events = append(events, Event{Status: StatusFiring, Alert: st.alert})
+			}
+			if st.firing {
+				c.Alerts = append(c.Alerts, st.alert)
+			}
+		}
+	}
+	for k, st := range e.states {
+		if !seen[k] {
+			if st.firing {
+				events = append(events, Event{Status: StatusResolved, Alert: st.alert, EndsAt: now})
+			}
+			delete(e.states, k)
+		}
+	}
+	e.mu.Unlock()
+	for _, ev := range events {
+		e.dispatch(ev)
+	}
+	return containers
+}
+
+// Firing lists the currently firing alerts, oldest first.
+func (e *Engine) Firing() []domain.Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var out []domain.Alert
+	for _, st := range e.states {
+		if st.firing {
+			out = append(out, st.alert)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Since.Equal(out[j].Since) {
+			return out[i].Since.Before(out[j].Since)
+		}
+		return out[i].Container+out[i].Rule < out[j].Container+out[j].Rule
+	})
+	return out
+}
+
+func (e *Engine) dispatch(ev Event) {
+	if len(e.notifiers) == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	e.wg.Add(1)
+	select {
+	case e.queue <- ev:
+	default:
+		e.wg.Done()
+		if e.logger != nil {
+			e.logger.Error("alert queue full; dropping notification", "rule", ev.Alert.Rule, "container", ev.Alert.Container)
+		}
+	}
+}
+
+// deliver sends queued events one at a time so receivers always see a
+// firing event before the matching resolve.
+func (e *Engine) deliver() {
+	defer close(e.stopped)
+	for ev := range e.queue {
+		for _, n := range e.notifiers {
+			ctx, cancel := context.WithTimeout(e.base, e.timeout)
+			if err := n.Notify(ctx, ev); err != nil && e.logger != nil {
+				e.logger.Error("alert notification failed", "rule", ev.Alert.Rule, "container", ev.Alert.Container, "error", err)
+			}
+			cancel()
+		}
+		e.wg.Done()
+	}
+}
+
+// Wait blocks until in-flight notifications have been delivered.
+func (e *Engine) Wait() { e.wg.Wait() }
+
+// Close stops the engine. Events already queued are still delivered for up
+// to drainTimeout; whatever is left after that is cancelled. Evaluate keeps
+// working but no longer notifies.
+func (e *Engine) Close() {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return
+	}
+	e.closed = true
+	close(e.queue)
+	e.mu.Unlock()
+	select {
+	case <-e.stopped:
+	case <-time.After(drainTimeout):
+		if e.logger != nil {
+			e.logger.Error("alert notifications still pending at shutdown; cancelling", "queued", len(e.queue))
+		}
+		e.abort()
+		<-e.stopped
+	}
+	e.abort()
+}
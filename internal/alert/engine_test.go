@@ -0,0 +1,240 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+type fakeDetails []domain.Field
+
+func (f fakeDetails) DetailFields() []domain.Field { return f }
+
+type recorder struct{ events []Event }
+
+func (r *recorder) Notify(ctx context.Context, ev Event) error {
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func newTestEngine(t *testing.T, rules []Rule, n ...Notifier) (*Engine, *time.Time) {
+	t.Helper()
+	e, err := NewEngine(rules, nil, n...)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	now := time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+	return e, &now
+}
+
+func TestEngine_ForDurationAndResolve(t *testing.T) {
+	rec := &recorder{}
+	e, now := newTestEngine(t, []Rule{{Name: "high-cpu", Expr: "cpu > 80", For: 2 * time.Minute}}, rec)
+	hot := []domain.Container{{ID: "a", Names: []string{"/api"}, Status: "running", CPUPercent: 95}}
+
+	out := e.Evaluate(context.Background(), hot)
+	e.Wait()
+	if len(out[0].Alerts) != 0 || len(rec.events) != 0 {
+		t.Fatalf("rule should be pending, got alerts=%v events=%v", out[0].Alerts, rec.events)
+	}
+	*now = now.Add(2 * time.Minute)
+	out = e.Evaluate(context.Background(), hot)
+	e.Wait()
+	if len(out[0].Alerts) != 1 || out[0].Alerts[0].Value != "cpu=95" {
+		t.Fatalf("expected firing alert, got %#v", out[0].Alerts)
+	}
+	*now = now.Add(time.Minute)
+	e.Evaluate(context.Background(), hot)
+	e.Wait()
+	if len(rec.events) != 1 || rec.events[0].Status != StatusFiring {
+		t.Fatalf("expected a single de-duplicated firing event, got %#v", rec.events)
+	}
+	cool := []domain.Container{{ID: "a", Names: []string{"/api"}, Status: "running", CPUPercent: 5}}
+	out = e.Evaluate(context.Background(), cool)
+	e.Wait()
+	if len(out[0].Alerts) != 0 {
+		t.Fatalf("expected alert to clear, got %#v", out[0].Alerts)
+	}
+	if len(rec.events) != 2 || rec.events[1].Status != StatusResolved || rec.events[1].EndsAt.IsZero() {
+		t.Fatalf("expected resolve event, got %#v", rec.events)
+	}
+	if len(e.Firing()) != 0 {
+		t.Errorf("expected nothing firing")
+	}
+}
+
+func TestEngine_ResolvesVanishedContainers(t *testing.T) {
+	rec := &recorder{}
+	e, _ := newTestEngine(t, []Rule{{Name: "unhealthy", Expr: "unhealthy"}}, rec)
+	sick := []domain.Container{{ID: "a", Status: "running", Health: &domain.Health{Status: domain.HealthUnhealthy}}}
+	e.Evaluate(context.Background(), sick)
+	e.Evaluate(context.Background(), nil)
+	e.Wait()
+	if len(rec.events) != 2 || rec.events[1].Status != StatusResolved {
+		t.Fatalf("expected firing then resolved, got %#v", rec.events)
+	}
+}
+
+func TestRule_UnexpectedExit(t *testing.T) {
+	r, err := compile(Rule{Name: "t", Expr: "unexpected_exit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		status string
+		l      domain.Lifecycle
+		want   bool
+	}{
+		{"exited", domain.Lifecycle{ExitCode: 1}, true},
+		{"exited", domain.Lifecycle{ExitCode: 0}, false},
+		{"exited", domain.Lifecycle{ExitCode: 143}, false},
+		{"exited", domain.Lifecycle{ExitCode: 137}, false},
+		{"exited", domain.Lifecycle{ExitCode: 137, OOMKilled: true}, true},
+		{"running", domain.Lifecycle{ExitCode: 1}, false},
+	}
+	for _, tc := range cases {
+		c := domain.Container{ID: "x", Status: tc.status, Lifecycle: tc.l}
+		if got, _ := r.match(c); got != tc.want {
+			t.Errorf("%s %+v = %v want %v", tc.status, tc.l, got, tc.want)
+		}
+	}
+}
+
+func TestRule_Expressions(t *testing.T) {
+	c := domain.Container{
+		ID: "x", Names: []string{"/api-1"}, Status: "exited", Type: domain.ContainerTypePostgreSQL,
+		MemoryMB: 950, MemoryLimitMB: 1000,
+		Lifecycle: domain.Lifecycle{ExitCode: 137, RestartStorm: true},
+		Details:   fakeDetails{domain.IntField("Max Conn", 200), domain.StringField("Mode", "standalone"), domain.StringField("Workers", "4"), domain.BoolField("Dashboard", false)},
+	}
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{"mem_pct > 90", true},
+		{"mem_pct>96", false},
+		{"unexpected_exit", false},
+		{"restart_storm and name =~ ^api-", true},
+		{"restart_storm and name =~ ^web-", false},
+		{`detail."Max Conn" >= 200`, true},
+		{`detail.Mode == standalone`, true},
+		{`detail.Missing == x`, false},
+		{`detail.Workers > 3`, true},
+		{`detail.Dashboard`, false},
+		{"type == postgresql && exit_code != 0", true},
+		{"oom", false},
+		{"health == none", true},
+	}
+	for _, tc := range cases {
+		r, err := compile(Rule{Name: "t", Expr: tc.expr})
+		if err != nil {
+			t.Fatalf("compile %q: %v", tc.expr, err)
+		}
+		if got, _ := r.match(c); got != tc.want {
+			t.Errorf("%q = %v want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestRule_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "bogus > 1", "cpu >", "cpu > 1 or mem > 2", "name =~ (", "cpu > 1 and"} {
+		if _, err := compile(Rule{Name: "bad", Expr: expr}); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
+	if _, err := NewEngine(DefaultRules(), nil); err != nil {
+		t.Fatalf("default rules should compile: %v", err)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	got := make(chan payload, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		got <- p
+	}))
+	defer srv.Close()
+	e, _ := newTestEngine(t, []Rule{{Name: "restart-storm", Expr: "restart_storm", Severity: "critical"}}, &WebhookNotifier{URL: srv.URL})
+	e.Evaluate(context.Background(), []domain.Container{{ID: "a", Names: []string{"/db"}, Lifecycle: domain.Lifecycle{RestartStorm: true}}})
+	e.Wait()
+	select {
+	case p := <-got:
+		if p.Status != StatusFiring || p.Rule != "restart-storm" || p.Container != "db" || p.Severity != "critical" {
+			t.Errorf("unexpected payload %#v", p)
+		}
+	default:
+		t.Fatal("webhook was not called")
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	n := &WebhookNotifier{URL: srv.URL}
+	if err := n.Notify(context.Background(), Event{Status: StatusFiring}); err == nil {
+		t.Fatal("expected error on 502")
+	}
+}
+
+func TestBellNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	b := &BellNotifier{W: &buf}
+	_ = b.Notify(context.Background(), Event{Status: StatusFiring})
+	_ = b.Notify(context.Background(), Event{Status: StatusResolved})
+	if buf.String() != "\a" {
+		t.Errorf("expected one bell, got %q", buf.String())
+	}
+}
+
+func TestValidate_ReportsEveryBadRule(t *testing.T) {
+	if err := Validate(DefaultRules()); err != nil {
+		t.Fatalf("default rules invalid: %v", err)
+	}
+	err := Validate([]Rule{{Name: "a", Expr: "cpu >"}, {Name: "b", Expr: "cpu > 1"}, {Name: "c", Expr: "nope > 1"}})
+	if err == nil || !strings.Contains(err.Error(), "alert rule a") || !strings.Contains(err.Error(), "alert rule c") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+type slowNotifier struct {
+	recorder
+	delay time.Duration
+}
+
+func (s *slowNotifier) Notify(ctx context.Context, ev Event) error {
+	time.Sleep(s.delay)
+	return s.recorder.Notify(ctx, ev)
+}
+
+func TestEngine_CloseDrainsQueue(t *testing.T) {
+	slow := &slowNotifier{delay: 20 * time.Millisecond}
+	e, _ := newTestEngine(t, []Rule{{Name: "down", Expr: `state == "exited"`}}, slow)
+	var down []domain.Container
+	for _, id := range []string{"a", "b", "c"} {
+		down = append(down, domain.Container{ID: id, Names: []string{"/" + id}, Status: "exited"})
+	}
+	e.Evaluate(context.Background(), down)
+	e.Close()
+	if len(slow.events) != 3 {
+		t.Fatalf("Close returned with %d of 3 events delivered", len(slow.events))
+	}
+	// later refreshes and a second Close are harmless
+	e.Evaluate(context.Background(), nil)
+	e.Close()
+	if len(slow.events) != 3 {
+		t.Errorf("events after Close: %#v", slow.events)
+	}
+}
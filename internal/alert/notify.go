@@ -0,0 +1,114 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+type payload struct {
+	Status      string    `json:"status"`
+	Rule        string    `json:"rule"`
+	Severity    string    `json:"severity,omitempty"`
+	ContainerID string    `json:"containerId"`
+	Container   string    `json:"container"`
+	Value       string    `json:"value,omitempty"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt,omitzero"`
+}
+
+func newPayload(ev Event) payload {
+	a := ev.Alert
+	return payload{Status: ev.Status, Rule: a.Rule, Severity: a.Severity, ContainerID: a.ContainerID, Container: a.Container, Value: a.Value, StartsAt: a.Since, EndsAt: ev.EndsAt}
+}
+
+// WebhookNotifier POSTs every firing and resolved event as JSON.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, ev Event) error {
+	body, err := json.Marshal(newPayload(ev))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook %s returned status=%d body=%s", w.URL, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+// CommandNotifier runs a command per event with the JSON payload on stdin
+// and the main fields exported as DOCKY_ALERT_* environment variables.
+type CommandNotifier struct {
+	Command []string
+}
+
+func (c *CommandNotifier) Notify(ctx context.Context, ev Event) error {
+	if len(c.Command) == 0 {
+		return fmt.Errorf("alert command is empty")
+	}
+	p := newPayload(ev)
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, c.Command[0], c.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"DOCKY_ALERT_STATUS="+p.Status,
+		"DOCKY_ALERT_RULE="+p.Rule,
+		"DOCKY_ALERT_SEVERITY="+p.Severity,
+		"DOCKY_ALERT_CONTAINER="+p.Container,
+		"DOCKY_ALERT_CONTAINER_ID="+p.ContainerID,
+		"DOCKY_ALERT_VALUE="+p.Value,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("alert command failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// BellNotifier rings the terminal bell when an alert starts firing.
+type BellNotifier struct {
+	W  io.Writer
+	mu sync.Mutex
+}
+
+func (b *BellNotifier) Notify(ctx context.Context, ev Event) error {
+	if ev.Status != StatusFiring {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	w := b.W
+	if w == nil {
+		w = os.Stderr
+	}
+	_, err := io.WriteString(w, "\a")
+	return err
+}
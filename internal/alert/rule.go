@@ -0,0 +1,288 @@
+package alert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+// Rule is a named condition evaluated against every container on each
+// refresh. Expr is a list of clauses joined by "and", for example
+// `cpu > 80`, `mem_pct > 90 and name =~ ^api-`, `unhealthy` or
+// `detail."Max Conn" >= 100`.
+type Rule struct {
+	Name     string
+	Expr     string
+	For      time.Duration
+	Severity string
+}
+
+func DefaultRules() []Rule {
+	return []Rule{
+		{Name: "unhealthy", Expr: "unhealthy", Severity: "critical"},
+		{Name: "restart-storm", Expr: "restart_storm", Severity: "critical"},
+		{Name: "oom-killed", Expr: "oom and state != running", Severity: "critical"},
+		{Name: "unexpected-exit", Expr: "unexpected_exit", Severity: "warning"},
+		{Name: "high-cpu", Expr: "cpu > 80", For: 2 * time.Minute, Severity: "warning"},
+		{Name: "high-memory", Expr: "mem_pct > 90", For: time.Minute, Severity: "warning"},
+	}
+}
+
+// Validate compiles rules without starting an engine and reports every rule
+// that would fail NewEngine.
+func Validate(rules []Rule) error {
+	var errs []error
+	for _, r := range rules {
+		if _, err := compile(r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type clause struct {
+	field string
+	op    string
+	str   string
+	num   float64
+	isNum bool
+	re    *regexp.Regexp
+}
+
+type compiledRule struct {
+	Rule
+	clauses []clause
+}
+
+func compile(r Rule) (compiledRule, error) {
+	if r.Name == "" {
+		return compiledRule{}, fmt.Errorf("alert rule with expression %q has no name", r.Expr)
+	}
+	toks, err := tokenize(r.Expr)
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("alert rule %s: %w", r.Name, err)
+	}
+	cr := compiledRule{Rule: r}
+	for len(toks) > 0 {
+		c := clause{field: toks[0]}
+		toks = toks[1:]
+		if !knownField(c.field) {
+			return compiledRule{}, fmt.Errorf("alert rule %s: unknown field %q", r.Name, c.field)
+		}
+		if len(toks) > 0 && isOp(toks[0]) {
+			if len(toks) < 2 {
+				return compiledRule{}, fmt.Errorf("alert rule %s: missing value after %s", r.Name, toks[0])
+			}
+			c.op, c.str = toks[0], toks[1]
+			toks = toks[2:]
+			if n, err := strconv.ParseFloat(c.str, 64); err == nil {
+				c.num, c.isNum = n, true
+			}
+			if c.op == "=~" {
+				if c.re, err = regexp.Compile(c.str); err != nil {
+					return compiledRule{}, fmt.Errorf("alert rule %s: %w", r.Name, err)
+				}
+			}
+		}
+		cr.clauses = append(cr.clauses, c)
+		if len(toks) > 0 {
+			if !strings.EqualFold(toks[0], "and") && toks[0] != "&&" {
+				return compiledRule{}, fmt.Errorf("alert rule %s: expected 'and', got %q", r.Name, toks[0])
+			}
+			toks = toks[1:]
+			if len(toks) == 0 {
+				return compiledRule{}, fmt.Errorf("alert rule %s: dangling 'and'", r.Name)
+			}
+		}
+	}
+	if len(cr.clauses) == 0 {
+		return compiledRule{}, fmt.Errorf("alert rule %s: empty expression", r.Name)
+	}
+	return cr, nil
+}
+
+func tokenize(s string) ([]string, error) {
+	var toks []string
+	for i := 0; i < len(s); {
+		ch := rune(s[i])
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in %q", s)
+			}
+			toks = append(toks, s[i+1:i+1+end])
+			i += end + 2
+		case strings.ContainsRune("<>=!~&", ch):
+			j := i + 1
+			for j < len(s) && strings.ContainsRune("<>=!~&", rune(s[j])) {
+				j++
+			}
+			toks = append(toks, s[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(s) && !unicode.IsSpace(rune(s[j])) && !strings.ContainsRune("<>=!\"", rune(s[j])) {
+				j++
+			}
+			// detail."Some Field" is a single token
+			if j < len(s) && s[j] == '"' && strings.HasSuffix(s[i:j], ".") {
+				end := strings.IndexByte(s[j+1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated quote in %q", s)
+				}
+				toks = append(toks, s[i:j]+s[j+1:j+1+end])
+				i = j + end + 2
+				continue
+			}
+			toks = append(toks, s[i:j])
+			i = j
+		}
+	}
+	return toks, nil
+}
+
+func isOp(s string) bool {
+	switch s {
+	case ">", ">=", "<", "<=", "==", "!=", "=~":
+		return true
+	}
+	return false
+}
+
+var fields = map[string]bool{
+	"cpu": true, "mem": true, "mem_pct": true, "restarts": true, "recent_restarts": true,
+	"exit_code": true, "state": true, "status": true, "health": true, "name": true,
+	"image": true, "type": true, "unhealthy": true, "restart_storm": true, "oom": true,
+	"unexpected_exit": true,
+}
+
+func knownField(f string) bool { return fields[f] || strings.HasPrefix(f, "detail.") }
+
+// value returns the field as a string plus, when it is numeric, as a number.
+func value(c domain.Container, field string) (string, float64, bool) {
+	num := func(f float64) (string, float64, bool) {
+		return strconv.FormatFloat(math.Round(f*10)/10, 'f', -1, 64), f, true
+	}
+	boolean := func(b bool) (string, float64, bool) {
+		if b {
+			return "true", 1, true
+		}
+		return "false", 0, true
+	}
+	state := domain.EffectiveState(c.Status, c.Health)
+	switch field {
+	case "cpu":
+		return num(c.CPUPercent)
+	case "mem":
+		return num(float64(c.MemoryMB))
+	case "mem_pct":
+		return num(c.MemoryPercent())
+	case "restarts":
+		return num(float64(c.Lifecycle.RestartCount))
+	case "recent_restarts":
+		return num(float64(c.Lifecycle.RecentRestarts))
+	case "exit_code":
+		return num(float64(c.Lifecycle.ExitCode))
+	case "state":
+		return state, 0, false
+	case "status":
+		return c.StatusText, 0, false
+	case "health":
+		if c.Health == nil {
+			return "none", 0, false
+		}
+		return string(c.Health.Status), 0, false
+	case "name":
+		return c.Name(), 0, false
+	case "image":
+		return c.Image, 0, false
+	case "type":
+		return string(c.Type), 0, false
+	case "unhealthy":
+		return boolean(state == domain.StateUnhealthy)
+	case "restart_storm":
+		return boolean(c.Lifecycle.RestartStorm)
+	case "oom":
+		return boolean(c.Lifecycle.OOMKilled)
+	case "unexpected_exit":
+		// 137 and 143 are what docker stop and docker kill leave behind
+		l := c.Lifecycle
+		stopped := l.ExitCode == 137 || l.ExitCode == 143
+		return boolean((c.Status == "exited" || c.Status == "dead") && (l.OOMKilled || l.ExitCode != 0 && !stopped))
+	}
+	if key, ok := strings.CutPrefix(field, "detail."); ok && c.Details != nil {
+		f, ok := domain.LookupField(c.Details.DetailFields(), key)
+		if !ok {
+			return "", 0, false
+		}
+		v := f.String()
+		if f.Numeric() {
+			return v, f.Num, true
+		}
+		if n, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return v, n, true
+		}
+		return v, 0, false
+	}
+	return "", 0, false
+}
+
+func (c clause) match(ct domain.Container) (bool, string) {
+	s, n, isNum := value(ct, c.field)
+	if c.op == "" {
+		return isNum && n != 0, c.field
+	}
+	observed := c.field + "=" + s
+	switch c.op {
+	case "=~":
+		return c.re.MatchString(s), observed
+	case "==":
+		if isNum && c.isNum {
+			return n == c.num, observed
+		}
+		return strings.EqualFold(s, c.str), observed
+	case "!=":
+		if isNum && c.isNum {
+			return n != c.num, observed
+		}
+		return !strings.EqualFold(s, c.str), observed
+	}
+	if !isNum || !c.isNum {
+		return false, observed
+	}
+	switch c.op {
+	case ">":
+		return n > c.num, observed
+	case ">=":
+		return n >= c.num, observed
+	case "<":
+		return n < c.num, observed
+	case "<=":
+		return n <= c.num, observed
+	}
+	return false, observed
+}
+
+func (r compiledRule) match(c domain.Container) (bool, string) {
+	var observed []string
+	for _, cl := range r.clauses {
+		ok, v := cl.match(c)
+		if !ok {
+			return false, ""
+		}
+		if cl.op != "" && cl.op != "=~" {
+			observed = append(observed, v)
+		}
+	}
+	return true, strings.Join(observed, " ")
+}
@@ -0,0 +1,251 @@
+// Package api exposes the orchestrator's container list over HTTP: a JSON
+// listing, a per-container view, a Server-Sent Events stream that pushes every
+// refresh and action endpoints mirroring the TUI keys.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/domain"
+	ilog "github.com/wosiu6/docky-go/internal/log"
+	"github.com/wosiu6/docky-go/internal/snapshot"
+)
+
+// keepAlive is how often an idle SSE stream gets a comment line so proxies
+// don't drop the connection.
+const keepAlive = 15 * time.Second
+
+type Server struct {
+	token   string
+	host    string
+	actions docker.ActionClient
+	logger  ilog.Logger
+	mux     *http.ServeMux
+
+	mu          sync.RWMutex
+	containers  []domain.Container
+	updated     time.Time
+	subscribers map[chan []byte]struct{}
+}
+
+// New builds the API. An empty token disables authentication, which is only
+// sensible when the listener is bound to localhost. A nil actions client
+// makes the action endpoints answer 501.
+func New(token, host string, actions docker.ActionClient, logger ilog.Logger) *Server {
+	s := &Server{token: token, host: host, actions: actions, logger: logger, mux: http.NewServeMux(), subscribers: make(map[chan []byte]struct{})}
+	s.mux.HandleFunc("GET /api/containers", s.list)
+	s.mux.HandleFunc("GET /api/containers/{id}", s.get)
+	s.mux.HandleFunc("POST /api/containers/{id}/{action}", s.action)
+	s.mux.HandleFunc("GET /api/stream", s.stream)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="docky-go"`)
+		writeError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized accepts the token as a bearer header or, because EventSource
+// cannot set headers, as a ?token= query parameter.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	got := r.URL.Query().Get("token")
+	if h := r.Header.Get("Authorization"); h != "" {
+		got, _ = strings.CutPrefix(h, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) == 1
+}
+
+func (s *Server) Consume(ctx context.Context, containers []domain.Container) {
+	s.mu.Lock()
+	s.containers = containers
+	s.updated = time.Now()
+	payload, err := json.Marshal(snapshot.New(containers, s.host, s.updated))
+	subs := make([]chan []byte, 0, len(s.subscribers))
+	for ch := range s.subscribers {
+		subs = append(subs, ch)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		s.logError("encode stream payload", err)
+		return
+	}
+	for _, ch := range subs {
+		// Drop the stale update rather than block the refresh loop on a
+		// slow client; it only ever needs the latest list.
+		select {
+		case <-ch:
+		default:
+		}
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
+
+func (s *Server) current() ([]domain.Container, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.containers, s.updated
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	containers, updated := s.current()
+	writeJSON(w, http.StatusOK, snapshot.New(containers, s.host, updated))
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.find(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, snapshot.FromDomain(c))
+}
+
+// find matches a full ID, then a name, then an ID prefix of at least 4
+// characters. It answers 404 when nothing matches and 409 when a prefix
+// matches more than one container.
+func (s *Server) find(w http.ResponseWriter, ref string) (domain.Container, bool) {
+	containers, _ := s.current()
+	name := strings.TrimPrefix(ref, "/")
+	for _, c := range containers {
+		if c.ID == ref {
+			return c, true
+		}
+	}
+	for _, c := range containers {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	var matches []domain.Container
+	if len(ref) >= 4 {
+		for _, c := range containers {
+			if strings.HasPrefix(c.ID, ref) {
+				matches = append(matches, c)
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		writeError(w, http.StatusNotFound, "container not found")
+	case 1:
+		return matches[0], true
+	default:
+		writeError(w, http.StatusConflict, fmt.Sprintf("%q matches %d containers", ref, len(matches)))
+	}
+	return domain.Container{}, false
+}
+
+func (s *Server) action(w http.ResponseWriter, r *http.Request) {
+	action, ok := docker.ParseAction(r.PathValue("action"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown action")
+		return
+	}
+	if s.actions == nil {
+		writeError(w, http.StatusNotImplemented, "actions are not available")
+		return
+	}
+	c, ok := s.find(w, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	if err := s.actions.ContainerAction(r.Context(), c.ID, action); err != nil {
+		status := http.StatusBadGateway
+		var he *docker.HTTPError
+		if errors.As(err, &he) {
+			switch {
+			case he.Status == http.StatusNotModified:
+				// the daemon's "already started/stopped"
+				status = http.StatusConflict
+			case he.Status >= 400 && he.Status < 500:
+				status = he.Status
+			}
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"id": c.ID, "name": c.Name(), "action": string(action), "result": "ok"})
+}
+
+func (s *Server) stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+	ch := make(chan []byte, 1)
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	containers, updated := s.current()
+	if !updated.IsZero() {
+		if b, err := json.Marshal(snapshot.New(containers, s.host, updated)); err == nil {
+			writeEvent(w, b)
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case b := <-ch:
+			writeEvent(w, b)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, data []byte) {
+	fmt.Fprintf(w, "event: containers\ndata: %s\n\n", data)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func (s *Server) logError(msg string, err error) {
+	if s.logger != nil {
+		s.logger.Error(msg, "error", err)
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/snapshot"
+)
+
+type fakeActions struct {
+	id     string
+	action docker.Action
+	err    error
+}
+
+func (f *fakeActions) ContainerAction(ctx context.Context, id string, action docker.Action) error {
+	f.id, f.action = id, action
+	return f.err
+}
+
+func sample() []domain.Container {
+	return []domain.Container{
+		{ID: "aaaa1111", Names: []string{"/api"}, Image: "api:1", Status: "running"},
+		{ID: "bbbb2222", Names: []string{"/db"}, Image: "postgres", Status: "exited"},
+	}
+}
+
+func do(t *testing.T, h http.Handler, method, target, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Auth(t *testing.T) {
+	s := New("secret", "box", nil, nil)
+	if rec := do(t, s, "GET", "/api/containers", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	if rec := do(t, s, "GET", "/api/containers", "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for wrong token, got %d", rec.Code)
+	}
+	if rec := do(t, s, "GET", "/api/containers?token=secret", ""); rec.Code != http.StatusOK {
+		t.Fatalf("expected query token to work, got %d", rec.Code)
+	}
+}
+
+func TestServer_ListAndGet(t *testing.T) {
+	s := New("t", "box", nil, nil)
+	s.Consume(context.Background(), sample())
+	rec := do(t, s, "GET", "/api/containers", "t")
+	var snap snapshot.Snapshot
+	if err := json.NewDecoder(rec.Body).Decode(&snap); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if snap.Version != snapshot.Version || len(snap.Containers) != 2 || snap.Host != "box" {
+		t.Fatalf("unexpected list: %#v", snap)
+	}
+	for _, ref := range []string{"bbbb2222", "bbbb", "db"} {
+		rec = do(t, s, "GET", "/api/containers/"+ref, "t")
+		var c snapshot.Container
+		if err := json.NewDecoder(rec.Body).Decode(&c); err != nil || c.ID != "bbbb2222" {
+			t.Errorf("lookup %q: code=%d container=%#v err=%v", ref, rec.Code, c, err)
+		}
+	}
+	if rec = do(t, s, "GET", "/api/containers/nope", "t"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestServer_FindPrefersExactMatches(t *testing.T) {
+	fa := &fakeActions{}
+	s := New("t", "box", fa, nil)
+	s.Consume(context.Background(), []domain.Container{
+		{ID: "beef1111", Names: []string{"/api"}},
+		{ID: "beef2222", Names: []string{"/beef"}},
+		{ID: "cafe3333", Names: []string{"/cache"}},
+	})
+	if rec := do(t, s, "POST", "/api/containers/beef/stop", "t"); rec.Code != http.StatusOK || fa.id != "beef2222" {
+		t.Errorf("expected the name to win over an ID prefix, got %d %q", rec.Code, fa.id)
+	}
+	fa.id = ""
+	if rec := do(t, s, "POST", "/api/containers/beef1/stop", "t"); rec.Code != http.StatusOK || fa.id != "beef1111" {
+		t.Errorf("expected a unique prefix to match, got %d %q", rec.Code, fa.id)
+	}
+	fa.id = ""
+	s.Consume(context.Background(), []domain.Container{{ID: "beef1111"}, {ID: "beef2222"}})
+	if rec := do(t, s, "POST", "/api/containers/beef/stop", "t"); rec.Code != http.StatusConflict || fa.id != "" {
+		t.Errorf("expected 409 for an ambiguous prefix, got %d %q", rec.Code, fa.id)
+	}
+}
+
+func TestServer_Actions(t *testing.T) {
+	fa := &fakeActions{}
+	s := New("t", "box", fa, nil)
+	s.Consume(context.Background(), sample())
+	if rec := do(t, s, "POST", "/api/containers/api/restart", "t"); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if fa.id != "aaaa1111" || fa.action != docker.ActionRestart {
+		t.Errorf("unexpected call %#v", fa)
+	}
+	if rec := do(t, s, "POST", "/api/containers/api/explode", "t"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown action, got %d", rec.Code)
+	}
+	fa.err = &docker.HTTPError{Op: "start", Status: 304}
+	if rec := do(t, s, "POST", "/api/containers/api/start", "t"); rec.Code != http.StatusConflict {
+		t.Errorf("expected 409 when already in that state, got %d", rec.Code)
+	}
+	fa.err = &docker.HTTPError{Op: "start", Status: 404}
+	if rec := do(t, s, "POST", "/api/containers/api/start", "t"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected daemon 404 to pass through, got %d", rec.Code)
+	}
+	if rec := do(t, New("t", "box", nil, nil), "POST", "/api/containers/api/stop", "t"); rec.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501 without an action client, got %d", rec.Code)
+	}
+}
+
+func TestServer_Stream(t *testing.T) {
+	s := New("t", "box", nil, nil)
+	s.Consume(context.Background(), sample()[:1])
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"/api/stream?token=t", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	r := bufio.NewReader(resp.Body)
+	next := func() snapshot.Snapshot {
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				var snap snapshot.Snapshot
+				if err := json.Unmarshal([]byte(data), &snap); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				return snap
+			}
+		}
+	}
+	if got := next(); len(got.Containers) != 1 {
+		t.Fatalf("expected initial push with 1 container, got %d", len(got.Containers))
+	}
+	s.Consume(context.Background(), sample())
+	if got := next(); len(got.Containers) != 2 {
+		t.Fatalf("expected refresh push with 2 containers, got %d", len(got.Containers))
+	}
+}
@@ -0,0 +1,304 @@
+// Package config loads docky-go's settings file.
+//
+// Settings are resolved in this order, each step overriding the one before:
+// built-in defaults, the YAML file ($XDG_CONFIG_HOME/docky-go/config.yaml or
+// --config), environment variables, then command-line flags. The flags live
+// in main and take their defaults from the resolved Config, which is what
+// makes them win.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Docker      Docker              `yaml:"docker"`
+	Refresh     Refresh             `yaml:"refresh"`
+	Sort        Sort                `yaml:"sort"`
+	View        string              `yaml:"view"`
+	Filter      string              `yaml:"filter"`
+	Theme       string              `yaml:"theme"`
+	Themes      map[string]Theme    `yaml:"themes"`
+	Keybindings map[string][]string `yaml:"keybindings"`
+	Exporters   Exporters           `yaml:"exporters"`
+	Alerts      Alerts              `yaml:"alerts"`
+	Masking     Masking             `yaml:"masking"`
+	Traefik     Traefik             `yaml:"traefik"`
+}
+
+type Docker struct {
+	// Host is a DOCKER_HOST style endpoint, e.g. unix:///var/run/docker.sock.
+	Host        string        `yaml:"host"`
+	Timeout     time.Duration `yaml:"timeout"`
+	PingTimeout time.Duration `yaml:"ping_timeout"`
+}
+
+type Refresh struct {
+	Interval      time.Duration `yaml:"interval"`
+	Concurrency   int           `yaml:"concurrency"`
+	StormRestarts int           `yaml:"storm_restarts"`
+	StormWindow   time.Duration `yaml:"storm_window"`
+}
+
+type Sort struct {
+	Key     string `yaml:"key"`
+	Reverse bool   `yaml:"reverse"`
+	Group   bool   `yaml:"group"`
+}
+
+// Theme is a user theme: a built-in base with some colours replaced. Colors
+// is keyed by role (text, accent, danger, ...) and Types by container type.
+type Theme struct {
+	Base   string            `yaml:"base"`
+	Colors map[string]string `yaml:"colors"`
+	Types  map[string]string `yaml:"types"`
+}
+
+type Exporters struct {
+	MetricsAddr string            `yaml:"metrics_addr"`
+	MetricsLive bool              `yaml:"metrics_live"`
+	Push        []string          `yaml:"push"`
+	InfluxToken string            `yaml:"influx_token"`
+	OTLPHeaders map[string]string `yaml:"otlp_headers"`
+}
+
+type Alerts struct {
+	// DefaultRules keeps the built-in rules next to Rules.
+	DefaultRules bool        `yaml:"default_rules"`
+	Rules        []AlertRule `yaml:"rules"`
+	Webhook      string      `yaml:"webhook"`
+	Command      string      `yaml:"command"`
+	Bell         bool        `yaml:"bell"`
+}
+
+type AlertRule struct {
+	Name     string        `yaml:"name"`
+	Expr     string        `yaml:"expr"`
+	For      time.Duration `yaml:"for"`
+	Severity string        `yaml:"severity"`
+}
+
+// Masking hides detail values whose field name contains one of Patterns,
+// case-insensitively, everywhere containers are shown or exported.
+type Masking struct {
+	Enabled  bool     `yaml:"enabled"`
+	Patterns []string `yaml:"patterns"`
+}
+
+type Traefik struct {
+	// API is the Traefik API's base URL, e.g. http://localhost:8080; when
+	// set, routes get their status and errors from it.
+	API string `yaml:"api"`
+}
+
+const (
+	ViewCards = "cards"
+	ViewTable = "table"
+)
+
+func Default() Config {
+	return Config{
+		Docker:  Docker{Timeout: 5 * time.Second, PingTimeout: 2 * time.Second},
+		Refresh: Refresh{Interval: time.Second, Concurrency: 8, StormRestarts: 3, StormWindow: 5 * time.Minute},
+		Sort:    Sort{Key: "name", Group: true},
+		View:    ViewCards,
+		Theme:   "auto",
+		Alerts:  Alerts{DefaultRules: true},
+		Masking: Masking{Enabled: true, Patterns: []string{"password", "passwd", "secret", "token", "access key", "api key", "private key"}},
+	}
+}
+
+// DefaultPath is $XDG_CONFIG_HOME/docky-go/config.yaml, falling back to
+// ~/.config.
+func DefaultPath() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "config.yaml"
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "docky-go", "config.yaml")
+}
+
+// Load reads path over the defaults. Unknown keys are an error; when the file
+// has only those, the returned Config still holds everything else it set, so
+// Problems can report them together with Validate.
+func Load(path string) (Config, error) {
+	cfg := Default()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// Problems splits a Load error into one message per bad key. It returns nil
+// for errors that are not about the file's content, such as a missing file.
+func Problems(err error) []string {
+	var te *yaml.TypeError
+	if errors.As(err, &te) {
+		return te.Errors
+	}
+	if err != nil && strings.HasPrefix(err.Error(), "yaml:") {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+// Validate reports values that are well-formed YAML but out of range.
+// Settings owned by other packages, such as sort keys or alert expressions,
+// are checked by the caller.
+func (c Config) Validate() []error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	if c.Docker.Host != "" {
+		u, err := url.Parse(c.Docker.Host)
+		check(err == nil && slices.Contains([]string{"unix", "npipe", "tcp"}, u.Scheme), "docker.host: %q is not a unix://, npipe:// or tcp:// endpoint", c.Docker.Host)
+	}
+	check(c.Docker.Timeout > 0, "docker.timeout: must be positive")
+	check(c.Docker.PingTimeout > 0, "docker.ping_timeout: must be positive")
+	check(c.Refresh.Interval >= 100*time.Millisecond, "refresh.interval: %s is below the 100ms minimum", c.Refresh.Interval)
+	check(c.Refresh.Concurrency > 0, "refresh.concurrency: must be at least 1")
+	check(c.Refresh.StormRestarts > 0, "refresh.storm_restarts: must be at least 1")
+	check(c.Refresh.StormWindow > 0, "refresh.storm_window: must be positive")
+	check(c.View == ViewCards || c.View == ViewTable, "view: %q is not %s or %s", c.View, ViewCards, ViewTable)
+	for i, r := range c.Alerts.Rules {
+		check(r.Name != "", "alerts.rules[%d]: name is required", i)
+		check(r.Expr != "", "alerts.rules[%d]: expr is required", i)
+		check(r.For >= 0, "alerts.rules[%d]: for must not be negative", i)
+	}
+	for i, p := range c.Masking.Patterns {
+		check(strings.TrimSpace(p) != "", "masking.patterns[%d]: empty pattern", i)
+	}
+	if c.Traefik.API != "" {
+		u, err := url.Parse(c.Traefik.API)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "traefik.api: %q is not an http:// or https:// URL", c.Traefik.API)
+	}
+	return errs
+}
+
+const redacted = "<redacted>"
+
+// Redacted is c with the exporter credentials replaced, for printing.
+func (c Config) Redacted() Config {
+	if c.Exporters.InfluxToken != "" {
+		c.Exporters.InfluxToken = redacted
+	}
+	if len(c.Exporters.OTLPHeaders) > 0 {
+		headers := make(map[string]string, len(c.Exporters.OTLPHeaders))
+		for k := range c.Exporters.OTLPHeaders {
+			headers[k] = redacted
+		}
+		c.Exporters.OTLPHeaders = headers
+	}
+	return c
+}
+
+// EnvVar is an environment variable that overrides a config key.
+type EnvVar struct {
+	Name string
+	Key  string
+	set  func(c *Config, v string) error
+}
+
+var EnvVars = []EnvVar{
+	{"DOCKER_HOST", "docker.host", func(c *Config, v string) error { c.Docker.Host = v; return nil }},
+	{"DOCKY_REFRESH_INTERVAL", "refresh.interval", func(c *Config, v string) error { return setDuration(&c.Refresh.Interval, v) }},
+	{"DOCKY_CONCURRENCY", "refresh.concurrency", func(c *Config, v string) error { return setInt(&c.Refresh.Concurrency, v) }},
+	{"DOCKY_SORT", "sort.key", func(c *Config, v string) error { c.Sort.Key = v; return nil }},
+	{"DOCKY_VIEW", "view", func(c *Config, v string) error { c.View = v; return nil }},
+	{"DOCKY_FILTER", "filter", func(c *Config, v string) error { c.Filter = v; return nil }},
+	{"DOCKY_THEME", "theme", func(c *Config, v string) error { c.Theme = v; return nil }},
+	{"DOCKY_METRICS_ADDR", "exporters.metrics_addr", func(c *Config, v string) error { c.Exporters.MetricsAddr = v; return nil }},
+	{"DOCKY_PUSH", "exporters.push", func(c *Config, v string) error { c.Exporters.Push = SplitList(v); return nil }},
+	{"DOCKY_INFLUX_TOKEN", "exporters.influx_token", func(c *Config, v string) error { c.Exporters.InfluxToken = v; return nil }},
+	{"OTEL_EXPORTER_OTLP_HEADERS", "exporters.otlp_headers", func(c *Config, v string) error { c.Exporters.OTLPHeaders = parseHeaders(v); return nil }},
+	{"DOCKY_ALERT_WEBHOOK", "alerts.webhook", func(c *Config, v string) error { c.Alerts.Webhook = v; return nil }},
+	{"DOCKY_ALERT_COMMAND", "alerts.command", func(c *Config, v string) error { c.Alerts.Command = v; return nil }},
+	{"DOCKY_ALERT_BELL", "alerts.bell", func(c *Config, v string) error { return setBool(&c.Alerts.Bell, v) }},
+	{"DOCKY_MASK", "masking.enabled", func(c *Config, v string) error { return setBool(&c.Masking.Enabled, v) }},
+	{"DOCKY_TRAEFIK_API", "traefik.api", func(c *Config, v string) error { c.Traefik.API = v; return nil }},
+}
+
+// ApplyEnv overrides c with every variable in EnvVars that getenv returns a
+// non-empty value for.
+func (c *Config) ApplyEnv(getenv func(string) string) error {
+	var errs []error
+	for _, e := range EnvVars {
+		if v := getenv(e.Name); v != "" {
+			if err := e.set(c, v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", e.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setDuration(d *time.Duration, v string) error {
+	parsed, err := time.ParseDuration(v)
+	if err == nil {
+		*d = parsed
+	}
+	return err
+}
+
+func setInt(n *int, v string) error {
+	parsed, err := strconv.Atoi(v)
+	if err == nil {
+		*n = parsed
+	}
+	return err
+}
+
+func setBool(b *bool, v string) error {
+	parsed, err := strconv.ParseBool(v)
+	if err == nil {
+		*b = parsed
+	}
+	return err
+}
+
+// SplitList splits a comma-separated flag or variable, dropping empty items.
+func SplitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// parseHeaders parses the OpenTelemetry "key1=value1,key2=value2" header list.
+func parseHeaders(spec string) map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(spec, ",") {
+		if k, v, ok := strings.Cut(pair, "="); ok {
+			headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return headers
+}
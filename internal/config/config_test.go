@@ -0,0 +1,166 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_OverridesDefaults(t *testing.T) {
+	path := writeConfig(t, `
+refresh:
+  interval: 3s
+sort:
+  key: cpu
+keybindings:
+  quit: [x]
+themes:
+  paper:
+    base: light
+    colors:
+      accent: "#FF00FF"
+    types:
+      redis: "160"
+alerts:
+  rules:
+    - name: busy
+      expr: cpu > 50
+      for: 1m
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Refresh.Interval != 3*time.Second || cfg.Sort.Key != "cpu" {
+		t.Fatalf("file values not applied: %+v", cfg)
+	}
+	if cfg.Refresh.Concurrency != 8 || !cfg.Sort.Group || cfg.Docker.PingTimeout != 2*time.Second {
+		t.Fatalf("defaults lost: %+v", cfg)
+	}
+	if len(cfg.Alerts.Rules) != 1 || cfg.Alerts.Rules[0].For != time.Minute {
+		t.Fatalf("rules = %+v", cfg.Alerts.Rules)
+	}
+	if got := cfg.Keybindings["quit"]; len(got) != 1 || got[0] != "x" {
+		t.Fatalf("keybindings = %v", cfg.Keybindings)
+	}
+	if th := cfg.Themes["paper"]; th.Base != "light" || th.Colors["accent"] != "#FF00FF" || th.Types["redis"] != "160" {
+		t.Fatalf("themes = %+v", cfg.Themes)
+	}
+}
+
+func TestLoad_ReportsEveryUnknownKey(t *testing.T) {
+	path := writeConfig(t, `
+docker:
+  hots: unix:///x.sock
+refresh:
+  interval: 2s
+colour: red
+`)
+	cfg, err := Load(path)
+	problems := Problems(err)
+	if len(problems) != 2 {
+		t.Fatalf("problems = %v", problems)
+	}
+	if !strings.Contains(problems[0], "hots") || !strings.Contains(problems[1], "colour") {
+		t.Fatalf("problems = %v", problems)
+	}
+	if cfg.Refresh.Interval != 2*time.Second {
+		t.Fatalf("known keys should still load, interval = %s", cfg.Refresh.Interval)
+	}
+}
+
+func TestLoad_EmptyAndMissing(t *testing.T) {
+	if _, err := Load(writeConfig(t, "")); err != nil {
+		t.Fatalf("empty file: %v", err)
+	}
+	_, err := Load(filepath.Join(t.TempDir(), "nope.yaml"))
+	if !os.IsNotExist(err) || Problems(err) != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+}
+
+func TestApplyEnv_OverridesFile(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "refresh:\n  interval: 3s\nview: table\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		"DOCKY_REFRESH_INTERVAL":     "5s",
+		"DOCKY_PUSH":                 "statsd://a:8125, graphite://b:2003,",
+		"OTEL_EXPORTER_OTLP_HEADERS": "x-key=abc, x-team = ops",
+		"DOCKY_MASK":                 "false",
+		"DOCKY_ALERT_BELL":           "0",
+	}
+	if err := cfg.ApplyEnv(func(k string) string { return env[k] }); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Refresh.Interval != 5*time.Second || cfg.View != ViewTable || cfg.Masking.Enabled || cfg.Alerts.Bell {
+		t.Fatalf("cfg = %+v", cfg)
+	}
+	if len(cfg.Exporters.Push) != 2 || cfg.Exporters.Push[1] != "graphite://b:2003" {
+		t.Fatalf("push = %q", cfg.Exporters.Push)
+	}
+	if cfg.Exporters.OTLPHeaders["x-team"] != "ops" {
+		t.Fatalf("headers = %v", cfg.Exporters.OTLPHeaders)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Exporters.InfluxToken = "tok"
+	cfg.Exporters.OTLPHeaders = map[string]string{"authorization": "Bearer abc"}
+	r := cfg.Redacted()
+	if r.Exporters.InfluxToken != redacted || r.Exporters.OTLPHeaders["authorization"] != redacted {
+		t.Errorf("not redacted: %+v", r.Exporters)
+	}
+	if cfg.Exporters.OTLPHeaders["authorization"] != "Bearer abc" {
+		t.Errorf("Redacted changed the original headers")
+	}
+}
+
+func TestApplyEnv_InvalidValueKeepsPrevious(t *testing.T) {
+	cfg := Default()
+	err := cfg.ApplyEnv(func(k string) string {
+		if k == "DOCKY_REFRESH_INTERVAL" {
+			return "soon"
+		}
+		return ""
+	})
+	if err == nil || !strings.Contains(err.Error(), "DOCKY_REFRESH_INTERVAL") {
+		t.Fatalf("err = %v", err)
+	}
+	if cfg.Refresh.Interval != time.Second {
+		t.Fatalf("interval = %s", cfg.Refresh.Interval)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if errs := Default().Validate(); len(errs) != 0 {
+		t.Fatalf("defaults invalid: %v", errs)
+	}
+	cfg := Default()
+	cfg.Docker.Host = "ssh://box"
+	cfg.Refresh.Interval = 10 * time.Millisecond
+	cfg.Refresh.Concurrency = 0
+	cfg.View = "grid"
+	cfg.Alerts.Rules = []AlertRule{{Expr: "cpu > 1"}}
+	var got []string
+	for _, err := range cfg.Validate() {
+		got = append(got, strings.SplitN(err.Error(), ":", 2)[0])
+	}
+	want := "docker.host refresh.interval refresh.concurrency view alerts.rules[0]"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("got %v, want %s", got, want)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
 	"time"
 )
@@ -37,10 +39,15 @@ func WithBaseURL(u string) Option        { return func(o *clientOptions) { o.bas
 func NewClient() (DockerClient, error) { return NewClientWithOptions() }
 func NewClientWithOptions(opts ...Option) (DockerClient, error) {
 	cfg := clientOptions{timeout: 5 * time.Second, baseURL: "http://docker"}
+	var transport *http.Transport
+	if u := tcpEndpoint(); u != nil {
+		cfg.baseURL, transport = "http://"+u.Host, &http.Transport{}
+	} else {
+		transport = buildTransport()
+	}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
-	transport := buildTransport()
 	return &dockerClientImpl{http: &http.Client{Transport: transport, Timeout: cfg.timeout}, url: cfg.baseURL}, nil
 }
 
@@ -130,3 +137,112 @@ func (c *dockerClientImpl) ContainerInspect(ctx context.Context, id string, dest
 
 func (c *dockerClientImpl) GetHttpClient() *http.Client { return c.http }
 func (c *dockerClientImpl) GetUrl() string              { return c.url }
+
+// HostName names the daemon being talked to: the host part of a tcp://
+// DOCKER_HOST, or the local machine's hostname for socket connections.
+func HostName() string {
+	if u := tcpEndpoint(); u != nil {
+		return u.Hostname()
+	}
+	if h, err := os.Hostname(); err == nil {
+		return h
+	}
+	return "localhost"
+}
+
+// ServiceHost is the name published container ports are reached at: the
+// daemon's host for a tcp:// DOCKER_HOST, otherwise localhost.
+func ServiceHost() string {
+	if u := tcpEndpoint(); u != nil {
+		return u.Hostname()
+	}
+	return "localhost"
+}
+
+// tcpEndpoint is DOCKER_HOST when it is a tcp:// address, the only remote
+// daemon the client talks to; the client speaks plain HTTP to it, without
+// TLS. HostName and ServiceHost go by it too, so containers are never named
+// or linked after a daemon the client isn't connected to.
+func tcpEndpoint() *url.URL {
+	u, err := url.Parse(os.Getenv("DOCKER_HOST"))
+	if err != nil || u.Scheme != "tcp" || u.Hostname() == "" {
+		return nil
+	}
+	return u
+}
+
+type Action string
+
+const (
+	ActionStart   Action = "start"
+	ActionStop    Action = "stop"
+	ActionRestart Action = "restart"
+	ActionPause   Action = "pause"
+	ActionUnpause Action = "unpause"
+)
+
+func ParseAction(s string) (Action, bool) {
+	switch a := Action(strings.ToLower(s)); a {
+	case ActionStart, ActionStop, ActionRestart, ActionPause, ActionUnpause:
+		return a, true
+	}
+	return "", false
+}
+
+// ActionClient is implemented by clients that can change container state.
+// It is kept apart from DockerClient so read-only callers and test doubles
+// don't have to implement it.
+type ActionClient interface {
+	ContainerAction(ctx context.Context, id string, action Action) error
+}
+
+func (c *dockerClientImpl) ContainerAction(ctx context.Context, id string, action Action) error {
+	url := fmt.Sprintf("%s/containers/%s/%s", c.url, id, action)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	// stop and restart wait for the container's grace period, which can
+	// outlast the client's default timeout.
+	client := *c.http
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return &HTTPError{Op: string(action), Status: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+	}
+	return nil
+}
+
+// NetworkClient is implemented by clients that can list networks. Like
+// ActionClient it is optional, so recordings and test doubles can do
+// without it.
+type NetworkClient interface {
+	ListNetworks(ctx context.Context, dest any) error
+}
+
+func (c *dockerClientImpl) ListNetworks(ctx context.Context, dest any) error {
+	return c.get(ctx, "networks", "/networks", dest)
+}
+
+// get decodes the JSON answer to a GET of path into dest.
+func (c *dockerClientImpl) get(ctx context.Context, op, path string, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return &HTTPError{Op: op, Status: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+	}
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
@@ -0,0 +1,109 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DiskClient is implemented by clients that can list images and volumes and
+// report disk usage. Like ActionClient it is optional.
+type DiskClient interface {
+	ListImages(ctx context.Context, dest any) error
+	ListVolumes(ctx context.Context, dest any) error
+	DiskUsage(ctx context.Context, dest any) error
+}
+
+type PruneTarget string
+
+const (
+	// PruneImages removes dangling images.
+	PruneImages PruneTarget = "images"
+	// PruneVolumes removes unused anonymous volumes; named volumes are kept.
+	// Callers remove them one by one with RemoveVolume.
+	PruneVolumes PruneTarget = "volumes"
+)
+
+type PruneReport struct {
+	Deleted        int
+	SpaceReclaimed uint64
+}
+
+// PruneClient is implemented by clients that can reclaim disk space. It is
+// kept apart from DiskClient for the same reason ActionClient is.
+type PruneClient interface {
+	Prune(ctx context.Context, target PruneTarget) (PruneReport, error)
+	RemoveVolume(ctx context.Context, name string) error
+}
+
+func (c *dockerClientImpl) ListImages(ctx context.Context, dest any) error {
+	return c.get(ctx, "images", "/images/json", dest)
+}
+
+func (c *dockerClientImpl) ListVolumes(ctx context.Context, dest any) error {
+	return c.get(ctx, "volumes", "/volumes", dest)
+}
+
+func (c *dockerClientImpl) DiskUsage(ctx context.Context, dest any) error {
+	return c.get(ctx, "df", "/system/df", dest)
+}
+
+// Prune removes dangling images. It refuses PruneVolumes: before API 1.42
+// /volumes/prune removes unused named volumes too, so volumes go through
+// RemoveVolume instead.
+func (c *dockerClientImpl) Prune(ctx context.Context, target PruneTarget) (PruneReport, error) {
+	if target == PruneVolumes {
+		return PruneReport{}, errors.New("prune volumes one by one with RemoveVolume")
+	}
+	if target != PruneImages {
+		return PruneReport{}, fmt.Errorf("unknown prune target %q", target)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/prune", c.url, target), nil)
+	if err != nil {
+		return PruneReport{}, err
+	}
+	// pruning many layers can outlast the client's default timeout
+	client := *c.http
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return PruneReport{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return PruneReport{}, &HTTPError{Op: "prune " + string(target), Status: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+	}
+	var v struct {
+		ImagesDeleted  []json.RawMessage `json:"ImagesDeleted"`
+		SpaceReclaimed uint64            `json:"SpaceReclaimed"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return PruneReport{}, err
+	}
+	return PruneReport{Deleted: len(v.ImagesDeleted), SpaceReclaimed: v.SpaceReclaimed}, nil
+}
+
+// RemoveVolume deletes one volume. The daemon answers 409 while a container
+// uses it.
+func (c *dockerClientImpl) RemoveVolume(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.url+"/volumes/"+url.PathEscape(name), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return &HTTPError{Op: "remove volume " + name, Status: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+	}
+	return nil
+}
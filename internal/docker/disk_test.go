@@ -0,0 +1,44 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPrune(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /images/prune":
+			w.Write([]byte(`{"ImagesDeleted": [{"Untagged": "a"}, {"Deleted": "sha256:1"}], "SpaceReclaimed": 1500}`))
+		case "POST /volumes/prune":
+			t.Error("volumes must not go through /volumes/prune")
+		case "DELETE /volumes/v1":
+			w.WriteHeader(http.StatusNoContent)
+		case "DELETE /volumes/busy":
+			http.Error(w, "volume is in use", http.StatusConflict)
+		default:
+			http.Error(w, "no", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c := &dockerClientImpl{http: srv.Client(), url: srv.URL}
+	if r, err := c.Prune(context.Background(), PruneImages); err != nil || r.Deleted != 2 || r.SpaceReclaimed != 1500 {
+		t.Errorf("images: %+v, %v", r, err)
+	}
+	if _, err := c.Prune(context.Background(), PruneVolumes); err == nil {
+		t.Error("expected volumes to be refused")
+	}
+	if err := c.RemoveVolume(context.Background(), "v1"); err != nil {
+		t.Errorf("remove volume: %v", err)
+	}
+	var he *HTTPError
+	if err := c.RemoveVolume(context.Background(), "busy"); !errors.As(err, &he) || he.Status != http.StatusConflict {
+		t.Errorf("expected a 409, got %v", err)
+	}
+	if _, err := c.Prune(context.Background(), "containers"); err == nil {
+		t.Error("expected an error for an unknown target")
+	}
+}
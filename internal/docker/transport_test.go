@@ -1,7 +1,11 @@
 package docker
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -15,3 +19,36 @@ func TestBuildTransport_NotNil(t *testing.T) {
 	}
 	_ = runtime.GOOS
 }
+
+func TestNewClient_TCPHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"Id": "remote1"}]`))
+	}))
+	defer srv.Close()
+	t.Setenv("DOCKER_HOST", strings.Replace(srv.URL, "http://", "tcp://", 1))
+
+	c, err := NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := c.ListContainers(context.Background())
+	if err != nil || len(list) != 1 || list[0]["Id"] != "remote1" {
+		t.Fatalf("containers = %v, %v", list, err)
+	}
+	if HostName() != "127.0.0.1" {
+		t.Errorf("host = %q", HostName())
+	}
+}
+
+func TestServiceHost_OnlyForTCP(t *testing.T) {
+	for _, h := range []string{"", "unix:///var/run/docker.sock", "ssh://user@box", "npipe:////./pipe/docker_engine"} {
+		t.Setenv("DOCKER_HOST", h)
+		if got := ServiceHost(); got != "localhost" {
+			t.Errorf("DOCKER_HOST=%q: service host %q, want localhost", h, got)
+		}
+	}
+}
@@ -20,7 +20,7 @@ func buildTransport() *http.Transport {
 		if len(host) > 7 && host[:7] == "unix://" {
 			candidates = append(candidates, host[7:])
 		}
-		// ignore tcp:// here; default http.Transport can handle it if we set baseURL accordingly elsewhere.
+		// tcp:// never gets here; NewClientWithOptions dials it directly
 	}
 	candidates = append(candidates, "/var/run/docker.sock")
 	if xdg := os.Getenv("XDG_RUNTIME_DIR"); xdg != "" {
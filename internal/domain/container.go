@@ -1,6 +1,11 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type ContainerType string
 
@@ -42,17 +47,220 @@ type Stats struct {
 	Collected  time.Time
 }
 
+type HealthStatus string
+
+const (
+	HealthNone      HealthStatus = ""
+	HealthStarting  HealthStatus = "starting"
+	HealthHealthy   HealthStatus = "healthy"
+	HealthUnhealthy HealthStatus = "unhealthy"
+)
+
+// StateUnhealthy is reported by EffectiveState for running containers whose
+// healthcheck is failing, so they sort and alert apart from healthy ones.
+const StateUnhealthy = "unhealthy"
+
+type HealthProbe struct {
+	Start    time.Time
+	End      time.Time
+	ExitCode int
+	Output   string
+}
+
+type Health struct {
+	Status        HealthStatus
+	FailingStreak int
+	Log           []HealthProbe
+}
+
+func EffectiveState(state string, h *Health) string {
+	if h != nil && h.Status == HealthUnhealthy && state == "running" {
+		return StateUnhealthy
+	}
+	return state
+}
+
+// StateRank orders states from most to least in need of attention.
+func StateRank(state string) int {
+	switch state {
+	case StateUnhealthy:
+		return 0
+	case "dead":
+		return 1
+	case "restarting":
+		return 2
+	case "exited":
+		return 3
+	case "paused":
+		return 4
+	case "created":
+		return 5
+	case "running":
+		return 6
+	default:
+		return 7
+	}
+}
+
+type Lifecycle struct {
+	RestartCount   int
+	ExitCode       int
+	OOMKilled      bool
+	Error          string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	RecentRestarts int
+	RestartStorm   bool
+}
+
+// Uptime is the time since the container last started, or zero when it is not
+// running or the start time is unknown.
+func (l Lifecycle) Uptime(state string, now time.Time) time.Duration {
+	if state != "running" || l.StartedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(l.StartedAt)
+}
+
+// ExitReason describes how the container last stopped, or "" if it never has.
+func (l Lifecycle) ExitReason() string {
+	if l.OOMKilled {
+		return fmt.Sprintf("exit %d (OOM killed)", l.ExitCode)
+	}
+	if l.FinishedAt.IsZero() && l.ExitCode == 0 {
+		return ""
+	}
+	if l.Error != "" {
+		return fmt.Sprintf("exit %d (%s)", l.ExitCode, l.Error)
+	}
+	switch l.ExitCode {
+	case 0:
+		return "exit 0 (completed)"
+	case 126:
+		return "exit 126 (not executable)"
+	case 127:
+		return "exit 127 (command not found)"
+	case 137:
+		return "exit 137 (SIGKILL)"
+	case 139:
+		return "exit 139 (SIGSEGV)"
+	case 143:
+		return "exit 143 (SIGTERM)"
+	default:
+		return fmt.Sprintf("exit %d", l.ExitCode)
+	}
+}
+
+type Alert struct {
+	Rule        string
+	Severity    string
+	ContainerID string
+	Container   string
+	Value       string
+	Since       time.Time
+}
+
+// DetailProvider is implemented by a strategy's extracted info. Fields come
+// in the order they should be shown.
 type DetailProvider interface {
-	DetailFields() map[string]string
+	DetailFields() []Field
+}
+
+// Port is one entry of the port list the daemon reports; PublicPort is zero
+// when the port is exposed but not published.
+type Port struct {
+	IP          string
+	PrivatePort uint16
+	PublicPort  uint16
+	Type        string
+}
+
+func (p Port) String() string {
+	if p.PublicPort == 0 {
+		return fmt.Sprintf("%d/%s", p.PrivatePort, p.Type)
+	}
+	if p.IP == "" || p.IP == "0.0.0.0" || p.IP == "::" {
+		return fmt.Sprintf("%d->%d/%s", p.PublicPort, p.PrivatePort, p.Type)
+	}
+	return fmt.Sprintf("%s:%d->%d/%s", p.IP, p.PublicPort, p.PrivatePort, p.Type)
 }
 
 type Container struct {
-	ID         string
-	Names      []string
-	Image      string
-	Status     string
-	CPUPercent float64
-	MemoryMB   uint64
-	Type       ContainerType
-	Details    DetailProvider
+	ID              string
+	Names           []string
+	Image           string
+	Status          string
+	StatusText      string
+	Labels          map[string]string
+	Health          *Health
+	Lifecycle       Lifecycle
+	CPUPercent      float64
+	MemoryMB        uint64
+	MemoryLimitMB   uint64
+	NetRxBytes      uint64
+	NetTxBytes      uint64
+	BlockReadBytes  uint64
+	BlockWriteBytes uint64
+	PIDs            uint64
+	Ports           []Port
+	Type            ContainerType
+	Details         DetailProvider
+	Alerts          []Alert
+	// URL is the container's web interface, see ServiceURL.
+	URL string
+	// Routes are the Traefik routers the container's labels declare.
+	Routes []Route
+	// Routing is set on Traefik containers to every route Traefik serves.
+	Routing *RoutingTable
+	// Networks are the container's endpoints, by network name.
+	Networks []Endpoint
+}
+
+func (c Container) Name() string {
+	if len(c.Names) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+func (c Container) MemoryPercent() float64 {
+	if c.MemoryLimitMB == 0 {
+		return 0
+	}
+	return float64(c.MemoryMB) / float64(c.MemoryLimitMB) * 100
+}
+
+const (
+	LabelComposeProject     = "com.docker.compose.project"
+	LabelComposeService     = "com.docker.compose.service"
+	LabelComposeNumber      = "com.docker.compose.container-number"
+	LabelComposeWorkingDir  = "com.docker.compose.project.working_dir"
+	LabelComposeConfigFiles = "com.docker.compose.project.config_files"
+)
+
+func (c Container) ComposeProject() string { return c.Labels[LabelComposeProject] }
+
+// Compose is what the compose labels say about a container; Project is empty
+// for containers not started by compose.
+type Compose struct {
+	Project     string
+	Service     string
+	Number      int
+	WorkingDir  string
+	ConfigFiles []string
+}
+
+func ComposeFromLabels(labels map[string]string) Compose {
+	c := Compose{
+		Project:    labels[LabelComposeProject],
+		Service:    labels[LabelComposeService],
+		WorkingDir: labels[LabelComposeWorkingDir],
+	}
+	c.Number, _ = strconv.Atoi(labels[LabelComposeNumber])
+	for _, f := range strings.Split(labels[LabelComposeConfigFiles], ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			c.ConfigFiles = append(c.ConfigFiles, f)
+		}
+	}
+	return c
 }
@@ -0,0 +1,98 @@
+package domain
+
+import "time"
+
+// Image is an image in the daemon's store. Containers are the names of the
+// containers created from it, running or not.
+type Image struct {
+	ID      string
+	Tags    []string
+	Created time.Time
+	Size    uint64
+	// SharedSize is the part of Size in layers other images use too, or -1
+	// when unknown.
+	SharedSize int64
+	Containers []string
+}
+
+// Dangling reports an untagged image, one a newer build or pull has
+// replaced.
+func (i Image) Dangling() bool { return len(i.Tags) == 0 }
+
+type Volume struct {
+	Name       string
+	Driver     string
+	Mountpoint string
+	// Anonymous volumes are the ones created without a name, for a
+	// container's VOLUME or an unnamed mount.
+	Anonymous bool
+	// Size is -1 when the daemon didn't report it.
+	Size       int64
+	Containers []string
+}
+
+// DiskTotal is the disk usage of one kind of object, as in docker system df.
+type DiskTotal struct {
+	Kind        string
+	Count       int
+	Active      int
+	Size        uint64
+	Reclaimable uint64
+}
+
+// DiskUsage is what the daemon stores and how much of it nothing uses. Err
+// is set when the sizes from /system/df are missing.
+type DiskUsage struct {
+	Images  []Image
+	Volumes []Volume
+	Totals  []DiskTotal
+	Err     string
+}
+
+func (d DiskUsage) Reclaimable() uint64 {
+	var n uint64
+	for _, t := range d.Totals {
+		n += t.Reclaimable
+	}
+	return n
+}
+
+// DanglingImages counts the images an image prune removes and the space
+// their own layers take.
+func (d DiskUsage) DanglingImages() (n int, size uint64) {
+	for _, i := range d.Images {
+		if i.Dangling() && len(i.Containers) == 0 {
+			n++
+			size += i.uniqueSize()
+		}
+	}
+	return n, size
+}
+
+// PrunableVolumes are the volumes a volume prune removes, the anonymous ones
+// no container mounts.
+func (d DiskUsage) PrunableVolumes() []Volume {
+	var out []Volume
+	for _, v := range d.Volumes {
+		if v.Anonymous && len(v.Containers) == 0 {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// UnusedVolumes counts the PrunableVolumes and their size where known.
+func (d DiskUsage) UnusedVolumes() (n int, size uint64) {
+	for _, v := range d.PrunableVolumes() {
+		n++
+		size += uint64(max(v.Size, 0))
+	}
+	return n, size
+}
+
+func (i Image) uniqueSize() uint64 {
+	if i.SharedSize <= 0 || uint64(i.SharedSize) > i.Size {
+		return i.Size
+	}
+	return i.Size - uint64(i.SharedSize)
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type FieldKind int
+
+const (
+	FieldString FieldKind = iota
+	FieldInt
+	FieldBytes
+	FieldDuration
+	FieldPercent
+	FieldURL
+	FieldBool
+	FieldSecret
+)
+
+// Field is one detail a strategy extracts, such as a database name or a
+// connection limit. Numeric kinds keep the number so exporters and alert
+// rules can use it; String formats any kind for display.
+type Field struct {
+	Name string
+	Kind FieldKind
+	// Text holds string, URL and secret values.
+	Text string
+	// Num holds int, bytes, percent and bool (1 or 0) values, and durations
+	// in seconds.
+	Num float64
+	// Unit is appended to int values, e.g. "players".
+	Unit string
+	// Minor fields are left off cards and only shown in the detail view.
+	Minor bool
+}
+
+func StringField(name, v string) Field {
+	return Field{Name: name, Kind: FieldString, Text: v}
+}
+
+func URLField(name, v string) Field {
+	return Field{Name: name, Kind: FieldURL, Text: v}
+}
+
+func SecretField(name, v string) Field {
+	return Field{Name: name, Kind: FieldSecret, Text: v}
+}
+
+func IntField(name string, v int) Field {
+	return Field{Name: name, Kind: FieldInt, Num: float64(v)}
+}
+
+func BytesField(name string, v uint64) Field {
+	return Field{Name: name, Kind: FieldBytes, Num: float64(v)}
+}
+
+func PercentField(name string, v float64) Field {
+	return Field{Name: name, Kind: FieldPercent, Num: v}
+}
+
+func DurationField(name string, d time.Duration) Field {
+	return Field{Name: name, Kind: FieldDuration, Num: d.Seconds()}
+}
+
+func BoolField(name string, v bool) Field {
+	f := Field{Name: name, Kind: FieldBool}
+	if v {
+		f.Num = 1
+	}
+	return f
+}
+
+func (f Field) WithUnit(unit string) Field { f.Unit = unit; return f }
+func (f Field) AsMinor() Field             { f.Minor = true; return f }
+
+// Numeric reports whether the value is a number rather than text.
+func (f Field) Numeric() bool {
+	switch f.Kind {
+	case FieldInt, FieldBytes, FieldDuration, FieldPercent, FieldBool:
+		return true
+	}
+	return false
+}
+
+func (f Field) Bool() bool { return f.Num != 0 }
+
+func (f Field) Duration() time.Duration { return time.Duration(f.Num * float64(time.Second)) }
+
+func (f Field) IsZero() bool {
+	if f.Numeric() {
+		return f.Num == 0 && f.Kind != FieldBool
+	}
+	return f.Text == ""
+}
+
+// String is the value as shown in the TUI and snapshots. Bytes use decimal
+// units like the rest of docky-go's I/O figures.
+func (f Field) String() string {
+	switch f.Kind {
+	case FieldInt:
+		s := strconv.FormatFloat(f.Num, 'f', -1, 64)
+		if f.Unit != "" {
+			s += " " + f.Unit
+		}
+		return s
+	case FieldBytes:
+		return formatBytes(uint64(f.Num))
+	case FieldDuration:
+		return f.Duration().String()
+	case FieldPercent:
+		return strconv.FormatFloat(f.Num, 'f', 1, 64) + "%"
+	case FieldBool:
+		if f.Bool() {
+			return "yes"
+		}
+		return "no"
+	}
+	return f.Text
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1000
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(b)/float64(div), "kMGTPE"[exp])
+}
+
+// Fields drops zero values, so a strategy can list every field it looked
+// for; false bools are kept.
+func Fields(fields ...Field) []Field {
+	out := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if !f.IsZero() {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func LookupField(fields []Field, name string) (Field, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
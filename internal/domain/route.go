@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Route is a Traefik HTTP router, as declared in a container's labels or
+// reported by the Traefik API.
+type Route struct {
+	// Router is the name without the provider suffix, e.g. "whoami".
+	Router      string
+	Provider    string
+	Rule        string
+	Hosts       []string
+	Path        string
+	Entrypoints []string
+	TLS         bool
+	Service     string
+	// Container is the name of the container whose labels declare the
+	// router, or empty for routers only the API knows about.
+	Container string
+	// Status and Errors come from the Traefik API and are empty without it.
+	Status string
+	Errors []string
+}
+
+// URL is the address the route serves, using its first host, or "" when
+// that doesn't make a WebURL.
+func (r Route) URL() string {
+	if len(r.Hosts) == 0 {
+		return ""
+	}
+	scheme := "http"
+	if r.TLS {
+		scheme = "https"
+	}
+	return WebURL(scheme + "://" + r.Hosts[0] + r.Path)
+}
+
+// RoutingTable is every route a Traefik instance serves. It is attached to
+// Traefik containers; Overview and Err are only set when the API is queried.
+type RoutingTable struct {
+	Routes   []Route
+	Overview *TraefikOverview
+	Err      string
+}
+
+type TraefikCounts struct {
+	Total, Warnings, Errors int
+}
+
+// TraefikOverview is the HTTP part of Traefik's /api/overview.
+type TraefikOverview struct {
+	Routers, Services, Middlewares TraefikCounts
+}
+
+var (
+	traefikRule = regexp.MustCompile(`^traefik\.http\.routers\.([^.]+)\.rule$`)
+	ruleHost    = regexp.MustCompile("Host\\(([^)]*)\\)")
+	ruleQuoted  = regexp.MustCompile("[`\"]([^`\"]+)[`\"]")
+	rulePath    = regexp.MustCompile("Path(?:Prefix)?\\(\\s*[`\"]([^`\"]+)[`\"]")
+)
+
+// RoutesFromLabels reads the routers a container declares with
+// traefik.http.routers.* labels, sorted by name. A container with
+// traefik.enable=false declares none.
+func RoutesFromLabels(labels map[string]string) []Route {
+	if labels["traefik.enable"] == "false" {
+		return nil
+	}
+	var routes []Route
+	for k, rule := range labels {
+		m := traefikRule.FindStringSubmatch(k)
+		if m == nil {
+			continue
+		}
+		prefix := "traefik.http.routers." + m[1] + "."
+		r := Route{Router: m[1], Provider: "docker", Service: labels[prefix+"service"]}
+		r.SetRule(rule)
+		for _, ep := range strings.Split(labels[prefix+"entrypoints"], ",") {
+			if ep = strings.TrimSpace(ep); ep != "" {
+				r.Entrypoints = append(r.Entrypoints, ep)
+			}
+		}
+		r.TLS = labels[prefix+"tls"] == "true" || labels[prefix+"tls.certresolver"] != "" ||
+			slices.Contains(r.Entrypoints, "websecure") || slices.Contains(r.Entrypoints, "https")
+		routes = append(routes, r)
+	}
+	slices.SortFunc(routes, func(a, b Route) int { return strings.Compare(a.Router, b.Router) })
+	return routes
+}
+
+// SetRule sets the rule along with the hosts and path it matches.
+func (r *Route) SetRule(rule string) {
+	r.Rule = rule
+	r.Hosts = nil
+	for _, m := range ruleHost.FindAllStringSubmatch(rule, -1) {
+		for _, q := range ruleQuoted.FindAllStringSubmatch(m[1], -1) {
+			if !slices.Contains(r.Hosts, q[1]) {
+				r.Hosts = append(r.Hosts, q[1])
+			}
+		}
+	}
+	r.Path = ""
+	if m := rulePath.FindStringSubmatch(rule); m != nil {
+		r.Path = m[1]
+	}
+}
+
+// RouteHosts lists the hosts of routes in order, without repeats.
+func RouteHosts(routes []Route) []string {
+	var hosts []string
+	for _, r := range routes {
+		for _, h := range r.Hosts {
+			if !slices.Contains(hosts, h) {
+				hosts = append(hosts, h)
+			}
+		}
+	}
+	return hosts
+}
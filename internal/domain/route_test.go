@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRoutesFromLabels(t *testing.T) {
+	routes := RoutesFromLabels(map[string]string{
+		"traefik.http.routers.web.rule":                          "Host(`b.example.com`) || Host(`a.example.com`, `b.example.com`)",
+		"traefik.http.routers.web.entrypoints":                   "web",
+		"traefik.http.routers.api.rule":                          "Host(\"api.example.com\") && PathPrefix(`/v1`)",
+		"traefik.http.routers.api.tls.certresolver":              "le",
+		"traefik.http.routers.api.service":                       "api-svc",
+		"traefik.http.services.api-svc.loadbalancer.server.port": "8080",
+	})
+	if len(routes) != 2 || routes[0].Router != "api" || routes[1].Router != "web" {
+		t.Fatalf("unexpected routes: %+v", routes)
+	}
+	if api := routes[0]; api.URL() != "https://api.example.com/v1" || api.Service != "api-svc" || api.Provider != "docker" {
+		t.Errorf("unexpected api route: %+v", api)
+	}
+	if web := routes[1]; fmt.Sprint(web.Hosts) != "[b.example.com a.example.com]" || web.TLS || fmt.Sprint(web.Entrypoints) != "[web]" {
+		t.Errorf("unexpected web route: %+v", web)
+	}
+	if got := RouteHosts(routes); fmt.Sprint(got) != "[api.example.com b.example.com a.example.com]" {
+		t.Errorf("hosts = %v", got)
+	}
+	if RoutesFromLabels(map[string]string{"traefik.enable": "false", "traefik.http.routers.x.rule": "Host(`x`)"}) != nil {
+		t.Error("disabled container declared routes")
+	}
+}
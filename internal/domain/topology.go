@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Endpoint is a container's attachment to a network.
+type Endpoint struct {
+	Network   string
+	NetworkID string
+	IPv4      string
+	IPv6      string
+	Aliases   []string
+}
+
+// Network is a user-defined network and the containers attached to it.
+type Network struct {
+	ID       string
+	Name     string
+	Driver   string
+	Scope    string
+	Internal bool
+	Subnets  []string
+	Members  []NetworkMember
+}
+
+type NetworkMember struct {
+	Container string
+	IPv4      string
+	IPv6      string
+	Aliases   []string
+}
+
+// AttachMembers fills in the members of networks from the endpoints of
+// containers, sorted by container name.
+func AttachMembers(networks []Network, containers []Container) {
+	for i := range networks {
+		n := &networks[i]
+		n.Members = nil
+		for _, c := range containers {
+			for _, e := range c.Networks {
+				if e.NetworkID == n.ID || (e.NetworkID == "" && e.Network == n.Name) {
+					n.Members = append(n.Members, NetworkMember{Container: c.Name(), IPv4: e.IPv4, IPv6: e.IPv6, Aliases: e.Aliases})
+				}
+			}
+		}
+		slices.SortFunc(n.Members, func(a, b NetworkMember) int { return cmp.Compare(a.Container, b.Container) })
+	}
+}
+
+// Binding is a port published on the host by a container.
+type Binding struct {
+	Port
+	Container string
+	// Conflict is set when another container publishes the same host port
+	// and protocol on an overlapping address.
+	Conflict bool
+}
+
+// Bindings lists the published ports of containers by host port.
+func Bindings(containers []Container) []Binding {
+	var out []Binding
+	for _, c := range containers {
+		for _, p := range c.Ports {
+			if p.PublicPort != 0 {
+				out = append(out, Binding{Port: p, Container: c.Name()})
+			}
+		}
+	}
+	for i := range out {
+		for j := range out {
+			a, b := out[i], out[j]
+			if a.Container != b.Container && a.PublicPort == b.PublicPort && a.Type == b.Type && overlaps(a.IP, b.IP) {
+				out[i].Conflict = true
+			}
+		}
+	}
+	slices.SortFunc(out, func(a, b Binding) int {
+		return cmp.Or(cmp.Compare(a.PublicPort, b.PublicPort), cmp.Compare(a.Type, b.Type),
+			cmp.Compare(a.IP, b.IP), cmp.Compare(a.Container, b.Container), cmp.Compare(a.PrivatePort, b.PrivatePort))
+	})
+	return out
+}
+
+// overlaps reports whether two host addresses can't both be bound to the
+// same port, which is when they are equal or either is every address.
+func overlaps(a, b string) bool {
+	all := func(ip string) bool { return ip == "" || ip == "0.0.0.0" || ip == "::" }
+	return a == b || all(a) || all(b)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBindings(t *testing.T) {
+	containers := []Container{
+		{Names: []string{"/web"}, Ports: []Port{{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}, {PrivatePort: 443, Type: "tcp"}}},
+		{Names: []string{"/admin"}, Ports: []Port{{IP: "127.0.0.1", PrivatePort: 8080, PublicPort: 8080, Type: "tcp"}}},
+		{Names: []string{"/dns"}, Ports: []Port{{IP: "0.0.0.0", PrivatePort: 53, PublicPort: 8080, Type: "udp"}}},
+		{Names: []string{"/a"}, Ports: []Port{{IP: "10.0.0.1", PrivatePort: 22, PublicPort: 2222, Type: "tcp"}}},
+		{Names: []string{"/b"}, Ports: []Port{{IP: "10.0.0.2", PrivatePort: 22, PublicPort: 2222, Type: "tcp"}}},
+	}
+	var got []string
+	for _, b := range Bindings(containers) {
+		got = append(got, fmt.Sprintf("%s %s %v", b.Container, b.Port, b.Conflict))
+	}
+	want := []string{
+		"a 10.0.0.1:2222->22/tcp false",
+		"b 10.0.0.2:2222->22/tcp false",
+		"web 8080->80/tcp true",
+		"admin 127.0.0.1:8080->8080/tcp true",
+		"dns 8080->53/udp false",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestAttachMembers(t *testing.T) {
+	networks := []Network{{ID: "n1", Name: "app"}, {ID: "n2", Name: "empty"}}
+	containers := []Container{
+		{Names: []string{"/web"}, Networks: []Endpoint{{Network: "app", NetworkID: "n1", IPv4: "172.20.0.3", Aliases: []string{"web"}}}},
+		{Names: []string{"/db"}, Networks: []Endpoint{{Network: "app", NetworkID: "n1", IPv4: "172.20.0.2"}, {Network: "bridge", NetworkID: "n0"}}},
+	}
+	AttachMembers(networks, containers)
+	if m := networks[0].Members; len(m) != 2 || m[0].Container != "db" || m[1].IPv4 != "172.20.0.3" || m[1].Aliases[0] != "web" {
+		t.Errorf("members = %+v", m)
+	}
+	if networks[1].Members != nil {
+		t.Errorf("empty network has members: %+v", networks[1].Members)
+	}
+}
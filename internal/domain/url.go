@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// LabelURL overrides the address docky-go derives for a container.
+const LabelURL = "docky.url"
+
+// ServiceURL is where a container's web interface is reached: its docky.url
+// label, else the first host of the Traefik routers in its labels, else the
+// public side of webPort, the port its type serves the interface on.
+// Published ports bound to every address are reached at host, the daemon's
+// host name. It returns "" when none of these apply; labels that don't hold
+// a WebURL are skipped.
+func ServiceURL(labels map[string]string, ports []Port, webPort uint16, host string) string {
+	if u := WebURL(strings.TrimSpace(labels[LabelURL])); u != "" {
+		return u
+	}
+	if u := traefikURL(labels); u != "" {
+		return u
+	}
+	if webPort == 0 {
+		return ""
+	}
+	for _, p := range ports {
+		if p.PrivatePort != webPort || p.PublicPort == 0 || p.Type != "tcp" {
+			continue
+		}
+		addr := host
+		if ip := net.ParseIP(p.IP); ip != nil && !ip.IsUnspecified() && !ip.IsLoopback() {
+			addr = p.IP
+		}
+		if addr == "" {
+			addr = "localhost"
+		}
+		scheme := "http"
+		if webPort == 443 || webPort == 8443 || webPort == 9443 {
+			scheme = "https"
+		}
+		return scheme + "://" + net.JoinHostPort(addr, strconv.Itoa(int(p.PublicPort)))
+	}
+	return ""
+}
+
+func traefikURL(labels map[string]string) string {
+	for _, r := range RoutesFromLabels(labels) {
+		if u := r.URL(); u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+// WebURL returns u when it is an absolute http or https URL without control
+// characters, and "" otherwise. URLs come from labels and the Traefik API and
+// end up in terminal escape sequences and the system's URL opener, so
+// anything else is dropped.
+func WebURL(u string) string {
+	for _, r := range u {
+		if r < 0x20 || r >= 0x7f && r <= 0x9f {
+			return ""
+		}
+	}
+	p, err := url.Parse(u)
+	if err != nil || p.Host == "" {
+		return ""
+	}
+	if s := strings.ToLower(p.Scheme); s != "http" && s != "https" {
+		return ""
+	}
+	return u
+}
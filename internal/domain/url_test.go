@@ -0,0 +1,61 @@
+package domain
+
+import "testing"
+
+func TestServiceURL(t *testing.T) {
+	published := []Port{
+		{PrivatePort: 22, PublicPort: 2222, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 3000, PublicPort: 13000, Type: "tcp"},
+	}
+	cases := []struct {
+		name    string
+		labels  map[string]string
+		ports   []Port
+		webPort uint16
+		host    string
+		want    string
+	}{
+		{"label wins", map[string]string{LabelURL: " https://grafana.lan ", "traefik.http.routers.g.rule": "Host(`g.example.com`)"}, published, 3000, "box", "https://grafana.lan"},
+		{"traefik host", map[string]string{"traefik.http.routers.g.rule": "Host(`g.example.com`)"}, published, 3000, "box", "http://g.example.com"},
+		{"traefik tls and path", map[string]string{
+			"traefik.http.routers.b.rule":        "Host(`a.example.com`, `b.example.com`) && PathPrefix(`/app`)",
+			"traefik.http.routers.b.entrypoints": "web, websecure",
+			"traefik.http.routers.c.rule":        "Host(`c.example.com`)",
+		}, nil, 0, "", "https://a.example.com/app"},
+		{"traefik disabled", map[string]string{"traefik.enable": "false", "traefik.http.routers.g.rule": "Host(`g.example.com`)"}, published, 3000, "box", "http://box:13000"},
+		{"rule without host", map[string]string{"traefik.http.routers.g.rule": "PathPrefix(`/api`)"}, nil, 0, "box", ""},
+		{"published web port on remote host", nil, published, 3000, "docker.lan", "http://docker.lan:13000"},
+		{"localhost by default", nil, published, 3000, "", "http://localhost:13000"},
+		{"bound address", nil, []Port{{IP: "192.168.1.5", PrivatePort: 9443, PublicPort: 9443, Type: "tcp"}}, 9443, "box", "https://192.168.1.5:9443"},
+		{"loopback uses host", nil, []Port{{IP: "127.0.0.1", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}}, 80, "box", "http://box:8080"},
+		{"not published", nil, []Port{{PrivatePort: 3000, Type: "tcp"}}, 3000, "box", ""},
+		{"udp only", nil, []Port{{PrivatePort: 3000, PublicPort: 3000, Type: "udp"}}, 3000, "box", ""},
+		{"no web port", nil, published, 0, "box", ""},
+		{"unsafe label falls through", map[string]string{LabelURL: "file:///etc/passwd"}, published, 3000, "box", "http://box:13000"},
+		{"host with escape", map[string]string{"traefik.http.routers.g.rule": "Host(`g.example.com\x1b]8;;`)"}, nil, 0, "box", ""},
+	}
+	for _, tc := range cases {
+		if got := ServiceURL(tc.labels, tc.ports, tc.webPort, tc.host); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestWebURL(t *testing.T) {
+	cases := map[string]string{
+		"http://grafana.lan":         "http://grafana.lan",
+		"HTTPS://grafana.lan:3000/d": "HTTPS://grafana.lan:3000/d",
+		"javascript:alert(1)":        "",
+		"file:///etc/passwd":         "",
+		"grafana.lan":                "",
+		"http://":                    "",
+		"http://a.lan/\x1b]8;;\x07":  "",
+		"http://a.lan/\u009b31m":     "",
+		"http://a.lan/\npath":        "",
+	}
+	for in, want := range cases {
+		if got := WebURL(in); got != want {
+			t.Errorf("WebURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// GraphiteSink writes the plaintext protocol over TCP as
+// prefix.host.name.metric value timestamp. The connection is kept open and
+// redialled after an error.
+type GraphiteSink struct {
+	Addr   string
+	Prefix string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (s *GraphiteSink) Name() string { return "graphite" }
+
+func (s *GraphiteSink) Send(ctx context.Context, points []Point) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", s.Addr)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		s.conn.SetWriteDeadline(deadline)
+	} else {
+		s.conn.SetWriteDeadline(time.Time{})
+	}
+	w := bufio.NewWriter(s.conn)
+	for _, p := range points {
+		base := metricPath(s.Prefix, p.Tag("host"), p.Tag("name"))
+		ts := p.Time.Unix()
+		for _, f := range p.Fields {
+			fmt.Fprintf(w, "%s.%s %s %d\n", base, f.Name, strconv.FormatFloat(f.Value, 'f', -1, 64), ts)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (s *GraphiteSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+var pathUnsafe = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// metricPath joins non-empty segments with dots after replacing anything a
+// dotted metric path can't hold.
+func metricPath(segments ...string) string {
+	var out string
+	for _, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		if out != "" {
+			out += "."
+		}
+		out += pathUnsafe.ReplaceAllString(seg, "_")
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// InfluxSink writes InfluxDB line protocol to a write endpoint such as
+// http://influx:8086/api/v2/write?org=o&bucket=b&precision=ns.
+type InfluxSink struct {
+	URL         string
+	Token       string
+	Measurement string
+	Client      *http.Client
+}
+
+func (s *InfluxSink) Name() string { return "influx" }
+
+func (s *InfluxSink) Send(ctx context.Context, points []Point) error {
+	var body bytes.Buffer
+	WriteLineProtocol(&body, s.measurement(), points)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Token "+s.Token)
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("influx write: status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+func (s *InfluxSink) measurement() string {
+	if s.Measurement == "" {
+		return "docky_container"
+	}
+	return s.Measurement
+}
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// WriteLineProtocol encodes one line per point with nanosecond timestamps.
+func WriteLineProtocol(w *bytes.Buffer, measurement string, points []Point) {
+	for _, p := range points {
+		w.WriteString(measurementEscaper.Replace(measurement))
+		for _, t := range p.Tags {
+			w.WriteByte(',')
+			w.WriteString(tagEscaper.Replace(t[0]))
+			w.WriteByte('=')
+			w.WriteString(tagEscaper.Replace(t[1]))
+		}
+		for i, f := range p.Fields {
+			if i == 0 {
+				w.WriteByte(' ')
+			} else {
+				w.WriteByte(',')
+			}
+			w.WriteString(tagEscaper.Replace(f.Name))
+			w.WriteByte('=')
+			w.WriteString(strconv.FormatFloat(f.Value, 'f', -1, 64))
+		}
+		w.WriteByte(' ')
+		w.WriteString(strconv.FormatInt(p.Time.UnixNano(), 10))
+		w.WriteByte('\n')
+	}
+}
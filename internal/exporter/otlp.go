@@ -0,0 +1,222 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// OTLPSink posts ExportMetricsServiceRequest messages over OTLP/HTTP with
+// protobuf encoding. Every container becomes a resource; its name, ID,
+// image, type, host and compose project are resource attributes, and with
+// Details set the strategy detail fields are added as docky.detail.*.
+//
+// The messages are encoded by hand with protowire to avoid pulling in the
+// generated OTLP packages and their gRPC dependencies.
+type OTLPSink struct {
+	URL     string
+	Headers map[string]string
+	Details bool
+	Client  *http.Client
+
+	once  sync.Once
+	start time.Time
+}
+
+func (s *OTLPSink) Name() string { return "otlp" }
+
+func (s *OTLPSink) Send(ctx context.Context, points []Point) error {
+	// Docker's counters have no reset time we can see, so cumulative sums
+	// start when the exporter does.
+	s.once.Do(func() { s.start = time.Now() })
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(EncodeOTLP(points, s.start, s.Details)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range s.Headers {
+		req.Header.Set(k, v)
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("otlp export: status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+// resourceKeys maps point tags to OpenTelemetry resource attribute names.
+var resourceKeys = map[string]string{
+	"name":            "container.name",
+	"id":              "container.id",
+	"image":           "container.image.name",
+	"type":            "docky.container.type",
+	"host":            "host.name",
+	"compose_project": "docker.compose.project",
+}
+
+// Field numbers from opentelemetry/proto/metrics/v1 and common/v1.
+const (
+	fieldResourceMetrics = 1 // ExportMetricsServiceRequest.resource_metrics
+
+	fieldResource     = 1 // ResourceMetrics.resource
+	fieldScopeMetrics = 2 // ResourceMetrics.scope_metrics
+	fieldAttributes   = 1 // Resource.attributes
+
+	fieldScope   = 1 // ScopeMetrics.scope
+	fieldMetrics = 2 // ScopeMetrics.metrics
+	fieldName    = 1 // InstrumentationScope.name, Metric.name, KeyValue.key
+	fieldValue   = 2 // KeyValue.value
+
+	fieldUnit       = 3 // Metric.unit
+	fieldGauge      = 5 // Metric.gauge
+	fieldSum        = 7 // Metric.sum
+	fieldDataPoints = 1 // Gauge.data_points, Sum.data_points
+	fieldTemporal   = 2 // Sum.aggregation_temporality
+	fieldMonotonic  = 3 // Sum.is_monotonic
+
+	fieldStartTime     = 2 // NumberDataPoint.start_time_unix_nano
+	fieldTime          = 3 // NumberDataPoint.time_unix_nano
+	fieldAsDouble      = 4 // NumberDataPoint.as_double
+	fieldPointAttrs    = 7 // NumberDataPoint.attributes
+	fieldStringValue   = 1 // AnyValue.string_value
+	fieldBoolValue     = 2 // AnyValue.bool_value
+	fieldIntValue      = 3 // AnyValue.int_value
+	fieldDoubleValue   = 4 // AnyValue.double_value
+	temporalCumulative = 2
+)
+
+const otlpScope = "github.com/wosiu6/docky-go"
+
+func EncodeOTLP(points []Point, start time.Time, details bool) []byte {
+	var req []byte
+	for _, p := range points {
+		req = appendMessage(req, fieldResourceMetrics, encodeResourceMetrics(p, start, details))
+	}
+	return req
+}
+
+func encodeResourceMetrics(p Point, start time.Time, details bool) []byte {
+	var res []byte
+	for _, t := range p.Tags {
+		if key, ok := resourceKeys[t[0]]; ok {
+			res = appendMessage(res, fieldAttributes, keyValue(key, t[1]))
+		}
+	}
+	if details {
+		for _, f := range p.Details {
+			res = appendMessage(res, fieldAttributes, attribute(detailKey(f.Name), detailValue(f)))
+		}
+	}
+
+	var scope []byte
+	scope = appendMessage(scope, fieldScope, protowire.AppendString(protowire.AppendTag(nil, fieldName, protowire.BytesType), otlpScope))
+	for _, f := range p.Fields {
+		scope = appendMessage(scope, fieldMetrics, encodeMetric("docky.container."+f.Name, f, p.Time, start, nil))
+	}
+	if state := p.Tag("state"); state != "" {
+		f := Field{Name: "state", Value: 1}
+		scope = appendMessage(scope, fieldMetrics, encodeMetric("docky.container.state", f, p.Time, start, keyValue("state", state)))
+	}
+
+	var rm []byte
+	rm = appendMessage(rm, fieldResource, res)
+	rm = appendMessage(rm, fieldScopeMetrics, scope)
+	return rm
+}
+
+func encodeMetric(name string, f Field, at, start time.Time, attr []byte) []byte {
+	var dp []byte
+	if f.Counter {
+		dp = protowire.AppendTag(dp, fieldStartTime, protowire.Fixed64Type)
+		dp = protowire.AppendFixed64(dp, uint64(start.UnixNano()))
+	}
+	dp = protowire.AppendTag(dp, fieldTime, protowire.Fixed64Type)
+	dp = protowire.AppendFixed64(dp, uint64(at.UnixNano()))
+	dp = protowire.AppendTag(dp, fieldAsDouble, protowire.Fixed64Type)
+	dp = protowire.AppendFixed64(dp, math.Float64bits(f.Value))
+	if attr != nil {
+		dp = appendMessage(dp, fieldPointAttrs, attr)
+	}
+
+	var m []byte
+	m = protowire.AppendTag(m, fieldName, protowire.BytesType)
+	m = protowire.AppendString(m, name)
+	m = protowire.AppendTag(m, fieldUnit, protowire.BytesType)
+	m = protowire.AppendString(m, unitFor(f.Name))
+	if f.Counter {
+		var sum []byte
+		sum = appendMessage(sum, fieldDataPoints, dp)
+		sum = protowire.AppendTag(sum, fieldTemporal, protowire.VarintType)
+		sum = protowire.AppendVarint(sum, temporalCumulative)
+		sum = protowire.AppendTag(sum, fieldMonotonic, protowire.VarintType)
+		sum = protowire.AppendVarint(sum, 1)
+		return appendMessage(m, fieldSum, sum)
+	}
+	return appendMessage(m, fieldGauge, appendMessage(nil, fieldDataPoints, dp))
+}
+
+func keyValue(key, value string) []byte {
+	return attribute(key, protowire.AppendString(protowire.AppendTag(nil, fieldStringValue, protowire.BytesType), value))
+}
+
+func attribute(key string, anyValue []byte) []byte {
+	var kv []byte
+	kv = protowire.AppendTag(kv, fieldName, protowire.BytesType)
+	kv = protowire.AppendString(kv, key)
+	return appendMessage(kv, fieldValue, anyValue)
+}
+
+// detailValue is f as an AnyValue: ints and byte counts as int_value,
+// percentages and durations in seconds as double_value, and the rest as
+// their display string.
+func detailValue(f domain.Field) []byte {
+	switch f.Kind {
+	case domain.FieldInt, domain.FieldBytes:
+		return protowire.AppendVarint(protowire.AppendTag(nil, fieldIntValue, protowire.VarintType), uint64(int64(f.Num)))
+	case domain.FieldPercent, domain.FieldDuration:
+		return protowire.AppendFixed64(protowire.AppendTag(nil, fieldDoubleValue, protowire.Fixed64Type), math.Float64bits(f.Num))
+	case domain.FieldBool:
+		return protowire.AppendVarint(protowire.AppendTag(nil, fieldBoolValue, protowire.VarintType), protowire.EncodeBool(f.Bool()))
+	}
+	return protowire.AppendString(protowire.AppendTag(nil, fieldStringValue, protowire.BytesType), f.String())
+}
+
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// unitFor gives UCUM units from the metric name suffix.
+func unitFor(name string) string {
+	switch {
+	case strings.Contains(name, "bytes"):
+		return "By"
+	case strings.HasSuffix(name, "percent"):
+		return "%"
+	default:
+		return "1"
+	}
+}
+
+// detailKey turns a detail label such as "Max Conn" into docky.detail.max_conn.
+func detailKey(label string) string {
+	return "docky.detail." + strings.ReplaceAll(strings.ToLower(strings.TrimSpace(label)), " ", "_")
+}
@@ -0,0 +1,160 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type detailFields []domain.Field
+
+func (d detailFields) DetailFields() []domain.Field { return d }
+
+// fields decodes one protobuf message into its fields, keeping raw bytes for
+// length-delimited values and the number for varint and fixed64 ones.
+func fields(t *testing.T, b []byte) map[protowire.Number][]any {
+	t.Helper()
+	out := map[protowire.Number][]any{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatalf("bad tag: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+		var v any
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(b)
+		default:
+			t.Fatalf("unexpected wire type %v", typ)
+		}
+		if n < 0 {
+			t.Fatalf("bad value: %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+		out[num] = append(out[num], v)
+	}
+	return out
+}
+
+// attrs decodes KeyValues, giving each value the Go type of its AnyValue
+// case: string, bool, int64 or float64.
+func attrs(t *testing.T, kvs []any) map[string]any {
+	out := map[string]any{}
+	for _, kv := range kvs {
+		f := fields(t, kv.([]byte))
+		value := fields(t, f[2][0].([]byte))
+		var v any
+		switch {
+		case value[1] != nil:
+			v = string(value[1][0].([]byte))
+		case value[2] != nil:
+			v = protowire.DecodeBool(value[2][0].(uint64))
+		case value[3] != nil:
+			v = int64(value[3][0].(uint64))
+		case value[4] != nil:
+			v = math.Float64frombits(value[4][0].(uint64))
+		}
+		out[string(f[1][0].([]byte))] = v
+	}
+	return out
+}
+
+func TestOTLPSinkAgainstReceiverStub(t *testing.T) {
+	var body []byte
+	var contentType, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ = io.ReadAll(r.Body)
+		contentType, auth = r.Header.Get("Content-Type"), r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	sink, err := ParseSink(strings.Replace(srv.URL, "http://", "otlp://", 1) + "?details=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.(*OTLPSink).Headers = map[string]string{"Authorization": "Bearer x"}
+	containers := sample()
+	containers[0].Details = detailFields{
+		domain.IntField("Max Conn", 100), domain.StringField("SSL Mode", "require"),
+		domain.BoolField("Dashboard", true), domain.PercentField("Hit Rate", 97.5),
+	}
+	at := time.Unix(1700000000, 0)
+	if err := sink.Send(context.Background(), Points(containers, "box", at)); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "application/x-protobuf" || auth != "Bearer x" {
+		t.Errorf("content type %q, auth %q", contentType, auth)
+	}
+
+	req := fields(t, body)
+	if len(req[1]) != 1 {
+		t.Fatalf("expected one resource, got %d", len(req[1]))
+	}
+	rm := fields(t, req[1][0].([]byte))
+	resource := attrs(t, fields(t, rm[1][0].([]byte))[1])
+	for k, want := range map[string]any{
+		"container.name": "api", "container.id": "0123456789ab", "container.image.name": "ghcr.io/acme/api:1",
+		"docky.container.type": "generic", "host.name": "box", "docker.compose.project": `shop"prod`,
+		"docky.detail.max_conn": int64(100), "docky.detail.ssl_mode": "require",
+		"docky.detail.dashboard": true, "docky.detail.hit_rate": 97.5,
+	} {
+		if resource[k] != want {
+			t.Errorf("resource %s = %#v, want %#v", k, resource[k], want)
+		}
+	}
+
+	scope := fields(t, rm[2][0].([]byte))
+	metrics := map[string]map[protowire.Number][]any{}
+	for _, m := range scope[2] {
+		f := fields(t, m.([]byte))
+		metrics[string(f[1][0].([]byte))] = f
+	}
+	cpu := metrics["docky.container.cpu_percent"]
+	if cpu == nil || string(cpu[3][0].([]byte)) != "%" || cpu[5] == nil {
+		t.Fatalf("cpu metric missing or not a gauge: %v", cpu)
+	}
+	dp := fields(t, fields(t, cpu[5][0].([]byte))[1][0].([]byte))
+	if math.Float64frombits(dp[4][0].(uint64)) != 12.5 || dp[3][0].(uint64) != uint64(at.UnixNano()) {
+		t.Errorf("cpu data point = %v", dp)
+	}
+	restarts := metrics["docky.container.restarts_total"]
+	if restarts == nil || restarts[7] == nil {
+		t.Fatalf("restarts should be a sum: %v", restarts)
+	}
+	sum := fields(t, restarts[7][0].([]byte))
+	if sum[2][0].(uint64) != 2 || sum[3][0].(uint64) != 1 {
+		t.Errorf("restarts sum should be cumulative and monotonic: %v", sum)
+	}
+	state := metrics["docky.container.state"]
+	stateDP := fields(t, fields(t, state[5][0].([]byte))[1][0].([]byte))
+	if got := attrs(t, stateDP[7]); got["state"] != "unhealthy" {
+		t.Errorf("state attributes = %v", got)
+	}
+}
+
+func TestOTLPDetailsAreOptIn(t *testing.T) {
+	containers := sample()
+	containers[0].Details = detailFields{domain.IntField("Max Conn", 100)}
+	body := EncodeOTLP(Points(containers, "box", time.Now()), time.Now(), false)
+	rm := fields(t, fields(t, body)[1][0].([]byte))
+	if _, ok := attrs(t, fields(t, rm[1][0].([]byte))[1])["docky.detail.max_conn"]; ok {
+		t.Error("detail attributes should be off by default")
+	}
+}
@@ -0,0 +1,174 @@
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+type Source interface {
+	FetchAll(ctx context.Context) ([]domain.Container, error)
+}
+
+// Prometheus serves container metrics in the Prometheus text format. When it
+// is registered as an orchestrator consumer it serves the last refresh;
+// otherwise every scrape triggers a fetch from Source.
+type Prometheus struct {
+	src  Source
+	host string
+	// MaxAge is how old the last refresh may get before scrapes report
+	// docky_up 0 and drop the container series, as when Docker is down and
+	// refreshes fail. Zero serves the last refresh forever.
+	MaxAge time.Duration
+
+	mu      sync.RWMutex
+	cache   []domain.Container
+	cached  bool
+	fetched time.Time
+}
+
+func NewPrometheus(src Source, host string) *Prometheus {
+	return &Prometheus{src: src, host: host}
+}
+
+func (p *Prometheus) Consume(ctx context.Context, containers []domain.Container) {
+	p.mu.Lock()
+	p.cache = containers
+	p.cached = true
+	p.fetched = time.Now()
+	p.mu.Unlock()
+}
+
+func (p *Prometheus) containers(ctx context.Context) ([]domain.Container, time.Time, error) {
+	p.mu.RLock()
+	cache, cached, fetched := p.cache, p.cached, p.fetched
+	p.mu.RUnlock()
+	if cached || p.src == nil {
+		if cached && p.MaxAge > 0 && time.Since(fetched) > p.MaxAge {
+			return nil, fetched, fmt.Errorf("no refresh since %s", fetched.Format(time.RFC3339))
+		}
+		return cache, fetched, nil
+	}
+	containers, err := p.src.FetchAll(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return containers, time.Now(), nil
+}
+
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	containers, fetched, err := p.containers(r.Context())
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	WriteMetrics(bw, containers, p.host)
+	up := 1
+	if err != nil {
+		up = 0
+	}
+	writeFamily(bw, "docky_up", "gauge", "Whether the last Docker fetch succeeded.")
+	fmt.Fprintf(bw, "docky_up{host=%s} %d\n", quote(p.host), up)
+	if !fetched.IsZero() {
+		writeFamily(bw, "docky_last_refresh_timestamp_seconds", "gauge", "When the served data was fetched from Docker.")
+		fmt.Fprintf(bw, "docky_last_refresh_timestamp_seconds{host=%s} %d\n", quote(p.host), fetched.Unix())
+	}
+	writeFamily(bw, "docky_scrape_duration_seconds", "gauge", "Time spent collecting container metrics.")
+	fmt.Fprintf(bw, "docky_scrape_duration_seconds{host=%s} %g\n", quote(p.host), time.Since(start).Seconds())
+}
+
+type metric struct {
+	name, kind, help string
+	value            func(c domain.Container) float64
+}
+
+var metrics = []metric{
+	{"docky_container_cpu_percent", "gauge", "CPU usage in percent of one core.", func(c domain.Container) float64 { return c.CPUPercent }},
+	{"docky_container_memory_bytes", "gauge", "Memory usage in bytes.", func(c domain.Container) float64 { return float64(c.MemoryMB) * 1024 * 1024 }},
+	{"docky_container_memory_limit_bytes", "gauge", "Memory limit in bytes.", func(c domain.Container) float64 { return float64(c.MemoryLimitMB) * 1024 * 1024 }},
+	{"docky_container_network_receive_bytes_total", "counter", "Bytes received on all interfaces.", func(c domain.Container) float64 { return float64(c.NetRxBytes) }},
+	{"docky_container_network_transmit_bytes_total", "counter", "Bytes transmitted on all interfaces.", func(c domain.Container) float64 { return float64(c.NetTxBytes) }},
+	{"docky_container_block_read_bytes_total", "counter", "Bytes read from block devices.", func(c domain.Container) float64 { return float64(c.BlockReadBytes) }},
+	{"docky_container_block_write_bytes_total", "counter", "Bytes written to block devices.", func(c domain.Container) float64 { return float64(c.BlockWriteBytes) }},
+	{"docky_container_pids", "gauge", "Number of processes.", func(c domain.Container) float64 { return float64(c.PIDs) }},
+	{"docky_container_restarts_total", "counter", "Restart count reported by the daemon.", func(c domain.Container) float64 { return float64(c.Lifecycle.RestartCount) }},
+	{"docky_container_oom_killed", "gauge", "1 if the last exit was an OOM kill.", func(c domain.Container) float64 { return boolFloat(c.Lifecycle.OOMKilled) }},
+	{"docky_container_restart_storm", "gauge", "1 while the container is restarting repeatedly.", func(c domain.Container) float64 { return boolFloat(c.Lifecycle.RestartStorm) }},
+	{"docky_container_alerts_firing", "gauge", "Number of alerts firing for the container; always 0 with --metrics-live.", func(c domain.Container) float64 { return float64(len(c.Alerts)) }},
+}
+
+// WriteMetrics renders the per-container families. It is exported so other
+// front-ends can reuse the exposition without going through HTTP.
+func WriteMetrics(w *bufio.Writer, containers []domain.Container, host string) {
+	labels := make([]string, len(containers))
+	for i, c := range containers {
+		labels[i] = containerLabels(c, host)
+	}
+	for _, m := range metrics {
+		writeFamily(w, m.name, m.kind, m.help)
+		for i, c := range containers {
+			fmt.Fprintf(w, "%s{%s} %s\n", m.name, labels[i], formatValue(m.value(c)))
+		}
+	}
+	writeFamily(w, "docky_container_state", "gauge", "Current container state; the series with value 1 is the active one.")
+	for i, c := range containers {
+		fmt.Fprintf(w, "docky_container_state{%s,state=%s} 1\n", labels[i], quote(domain.EffectiveState(c.Status, c.Health)))
+	}
+	writeFamily(w, "docky_container_health", "gauge", "Healthcheck status; containers without a healthcheck report none.")
+	for i, c := range containers {
+		health := "none"
+		if c.Health != nil {
+			health = string(c.Health.Status)
+		}
+		fmt.Fprintf(w, "docky_container_health{%s,health=%s} 1\n", labels[i], quote(health))
+	}
+}
+
+func writeFamily(w *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func containerLabels(c domain.Container, host string) string {
+	pairs := [][2]string{
+		{"id", shortID(c.ID)},
+		{"name", c.Name()},
+		{"image", c.Image},
+		{"type", string(c.Type)},
+		{"host", host},
+		{"compose_project", c.ComposeProject()},
+	}
+	parts := make([]string, len(pairs))
+	for i, p := range pairs {
+		parts[i] = p[0] + "=" + quote(p[1])
+	}
+	return strings.Join(parts, ",")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote renders a label value with the exposition format's escaping, which
+// only covers backslash, double quote and newline.
+func quote(s string) string { return `"` + labelEscaper.Replace(s) + `"` }
+
+func formatValue(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+
+func boolFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
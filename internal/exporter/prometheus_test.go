@@ -0,0 +1,100 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+type fakeSource struct {
+	containers []domain.Container
+	err        error
+	calls      int
+}
+
+func (f *fakeSource) FetchAll(ctx context.Context) ([]domain.Container, error) {
+	f.calls++
+	return f.containers, f.err
+}
+
+func sample() []domain.Container {
+	return []domain.Container{{
+		ID: "0123456789abcdef", Names: []string{"/api"}, Image: "ghcr.io/acme/api:1", Type: domain.ContainerTypeGeneric,
+		Status: "running", CPUPercent: 12.5, MemoryMB: 2, NetRxBytes: 100, BlockWriteBytes: 7,
+		Labels:    map[string]string{domain.LabelComposeProject: `shop"prod`},
+		Health:    &domain.Health{Status: domain.HealthUnhealthy},
+		Lifecycle: domain.Lifecycle{RestartCount: 4},
+	}}
+}
+
+func scrape(t *testing.T, p *Prometheus) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	b, _ := io.ReadAll(rec.Body)
+	return string(b)
+}
+
+func TestPrometheus_ScrapeFetchesWithoutCache(t *testing.T) {
+	src := &fakeSource{containers: sample()}
+	out := scrape(t, NewPrometheus(src, "box"))
+	if src.calls != 1 {
+		t.Fatalf("expected one fetch per scrape, got %d", src.calls)
+	}
+	labels := `id="0123456789ab",name="api",image="ghcr.io/acme/api:1",type="generic",host="box",compose_project="shop\"prod"`
+	for _, want := range []string{
+		"# TYPE docky_container_cpu_percent gauge",
+		"docky_container_cpu_percent{" + labels + "} 12.5",
+		"docky_container_memory_bytes{" + labels + "} 2.097152e+06",
+		"docky_container_network_receive_bytes_total{" + labels + "} 100",
+		"docky_container_block_write_bytes_total{" + labels + "} 7",
+		"docky_container_restarts_total{" + labels + "} 4",
+		"docky_container_state{" + labels + `,state="unhealthy"} 1`,
+		"docky_container_health{" + labels + `,health="unhealthy"} 1`,
+		`docky_up{host="box"} 1`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("missing %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrometheus_ServesCacheWhenConsuming(t *testing.T) {
+	src := &fakeSource{err: errors.New("should not be called")}
+	p := NewPrometheus(src, "box")
+	p.Consume(context.Background(), sample())
+	out := scrape(t, p)
+	if src.calls != 0 {
+		t.Fatalf("expected cached data, got %d fetches", src.calls)
+	}
+	if !strings.Contains(out, `name="api"`) {
+		t.Errorf("expected cached container in output")
+	}
+}
+
+func TestPrometheus_StaleCache(t *testing.T) {
+	p := NewPrometheus(nil, "box")
+	p.MaxAge = time.Minute
+	p.Consume(context.Background(), sample())
+	if out := scrape(t, p); !strings.Contains(out, `docky_up{host="box"} 1`) {
+		t.Fatalf("expected fresh cache to be up, got:\n%s", out)
+	}
+	p.fetched = time.Now().Add(-2 * time.Minute)
+	out := scrape(t, p)
+	if !strings.Contains(out, `docky_up{host="box"} 0`) || strings.Contains(out, `name="api"`) {
+		t.Errorf("expected stale cache to be down without series, got:\n%s", out)
+	}
+}
+
+func TestPrometheus_FetchError(t *testing.T) {
+	out := scrape(t, NewPrometheus(&fakeSource{err: errors.New("down")}, "box"))
+	if !strings.Contains(out, `docky_up{host="box"} 0`) {
+		t.Errorf("expected docky_up 0, got:\n%s", out)
+	}
+}
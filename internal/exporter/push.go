@@ -0,0 +1,252 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	ilog "github.com/wosiu6/docky-go/internal/log"
+)
+
+// Point is one container's metrics at one refresh, the unit push sinks encode.
+type Point struct {
+	Time    time.Time
+	Tags    [][2]string
+	Fields  []Field
+	Details []domain.Field
+}
+
+type Field struct {
+	Name    string
+	Value   float64
+	Counter bool
+}
+
+// Points converts a refresh using the same metric table as the Prometheus
+// exposition, minus the docky_container_ prefix. Tags with empty values are
+// left out because line protocol rejects them.
+func Points(containers []domain.Container, host string, now time.Time) []Point {
+	out := make([]Point, 0, len(containers))
+	for _, c := range containers {
+		p := Point{Time: now}
+		for _, t := range [][2]string{
+			{"name", c.Name()}, {"id", shortID(c.ID)}, {"image", c.Image}, {"type", string(c.Type)},
+			{"host", host}, {"compose_project", c.ComposeProject()},
+			{"state", domain.EffectiveState(c.Status, c.Health)},
+		} {
+			if t[1] != "" {
+				p.Tags = append(p.Tags, t)
+			}
+		}
+		for _, m := range metrics {
+			p.Fields = append(p.Fields, Field{strings.TrimPrefix(m.name, "docky_container_"), m.value(c), m.kind == "counter"})
+		}
+		if c.Details != nil {
+			p.Details = c.Details.DetailFields()
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func (p Point) Tag(name string) string {
+	for _, t := range p.Tags {
+		if t[0] == name {
+			return t[1]
+		}
+	}
+	return ""
+}
+
+// Sink delivers a batch of points to a monitoring backend. Send must either
+// deliver the whole batch or return an error so the batch is retried.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, points []Point) error
+}
+
+type PushOptions struct {
+	BatchSize  int
+	MaxBuffer  int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	Timeout    time.Duration
+}
+
+func DefaultPushOptions() PushOptions {
+	return PushOptions{BatchSize: 500, MaxBuffer: 10000, MinBackoff: time.Second, MaxBackoff: time.Minute, Timeout: 10 * time.Second}
+}
+
+// Pusher is an orchestrator consumer that buffers every refresh and sends it
+// to a Sink from a background worker, so a slow or unreachable backend never
+// stalls the refresh loop. While the sink is down points pile up to
+// MaxBuffer, after which the oldest are dropped.
+type Pusher struct {
+	sink   Sink
+	host   string
+	opts   PushOptions
+	logger ilog.Logger
+
+	mu      sync.Mutex
+	buf     []Point
+	dropped int
+	wake    chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func NewPusher(sink Sink, host string, opts PushOptions, logger ilog.Logger) *Pusher {
+	p := &Pusher{sink: sink, host: host, opts: opts, logger: logger, wake: make(chan struct{}, 1), done: make(chan struct{}), stopped: make(chan struct{})}
+	go p.run()
+	return p
+}
+
+func (p *Pusher) Consume(ctx context.Context, containers []domain.Container) {
+	points := Points(containers, p.host, time.Now())
+	p.mu.Lock()
+	p.buf = append(p.buf, points...)
+	if over := len(p.buf) - p.opts.MaxBuffer; over > 0 {
+		p.buf = p.buf[over:]
+		p.dropped += over
+	}
+	p.mu.Unlock()
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Buffered reports how many points are waiting and how many were dropped
+// because the buffer was full.
+func (p *Pusher) Buffered() (waiting, dropped int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.buf), p.dropped
+}
+
+// Close stops the worker after one last attempt to flush the buffer.
+func (p *Pusher) Close() {
+	close(p.done)
+	<-p.stopped
+	if c, ok := p.sink.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+func (p *Pusher) run() {
+	defer close(p.stopped)
+	backoff := time.Duration(0)
+	failing := false
+	// retry is armed once per failed flush and left alone by wakes, which
+	// arrive every refresh and would otherwise keep pushing it back
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-p.done:
+			p.flush()
+			return
+		case <-p.wake:
+			if retry != nil {
+				// keep buffering until the backoff expires
+				continue
+			}
+		case <-retry:
+			retry = nil
+		}
+		if err := p.flush(); err != nil {
+			if !failing && p.logger != nil {
+				p.logger.Error("push failed; buffering", "sink", p.sink.Name(), "error", err)
+			}
+			failing = true
+			backoff = min(max(backoff*2, p.opts.MinBackoff), p.opts.MaxBackoff)
+			retry = time.After(backoff)
+			continue
+		}
+		if failing && p.logger != nil {
+			p.logger.Info("push recovered", "sink", p.sink.Name())
+		}
+		failing, backoff = false, 0
+	}
+}
+
+// flush sends the buffer in batches and stops at the first failure, leaving
+// the unsent points for the next attempt.
+func (p *Pusher) flush() error {
+	for {
+		p.mu.Lock()
+		n := min(len(p.buf), p.opts.BatchSize)
+		batch := append([]Point(nil), p.buf[:n]...)
+		dropped := p.dropped
+		p.mu.Unlock()
+		if n == 0 {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), p.opts.Timeout)
+		err := p.sink.Send(ctx, batch)
+		cancel()
+		if err != nil {
+			return err
+		}
+		p.mu.Lock()
+		// points dropped for space during the send came off the front,
+		// possibly out of this batch already
+		if rest := n - (p.dropped - dropped); rest > 0 {
+			p.buf = p.buf[rest:]
+		}
+		p.mu.Unlock()
+	}
+}
+
+// ParseSink builds a sink from a URL:
+//
+//	influx://host:8086/api/v2/write?org=o&bucket=b   (influxs:// for HTTPS)
+//	graphite://host:2003?prefix=docky
+//	statsd://host:8125?prefix=docky
+//	otlp://collector:4318?details=1                (otlps:// for HTTPS)
+func ParseSink(spec string) (Sink, error) {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("push target %q has no host", spec)
+	}
+	prefix := u.Query().Get("prefix")
+	if prefix == "" {
+		prefix = "docky"
+	}
+	switch u.Scheme {
+	case "influx", "influxs":
+		target := *u
+		target.Scheme = "http"
+		if u.Scheme == "influxs" {
+			target.Scheme = "https"
+		}
+		if target.Path == "" || target.Path == "/" {
+			target.Path = "/api/v2/write"
+		}
+		return &InfluxSink{URL: target.String()}, nil
+	case "otlp", "otlps":
+		target := url.URL{Scheme: "http", Host: u.Host, Path: u.Path}
+		if u.Scheme == "otlps" {
+			target.Scheme = "https"
+		}
+		if target.Path == "" || target.Path == "/" {
+			target.Path = "/v1/metrics"
+		}
+		details, _ := strconv.ParseBool(u.Query().Get("details"))
+		return &OTLPSink{URL: target.String(), Details: details}, nil
+	case "graphite":
+		return &GraphiteSink{Addr: u.Host, Prefix: prefix}, nil
+	case "statsd":
+		return &StatsDSink{Addr: u.Host, Prefix: prefix}, nil
+	default:
+		return nil, fmt.Errorf("unknown push scheme %q (want influx, influxs, otlp, otlps, graphite or statsd)", u.Scheme)
+	}
+}
@@ -0,0 +1,213 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriteLineProtocolEscapes(t *testing.T) {
+	at := time.Unix(1700000000, 5)
+	var buf bytes.Buffer
+	WriteLineProtocol(&buf, "docky container", Points(sample(), "my box", at))
+	line := buf.String()
+	for _, want := range []string{
+		`docky\ container,name=api,id=0123456789ab,image=ghcr.io/acme/api:1,type=generic,host=my\ box,compose_project=shop"prod,state=unhealthy `,
+		" cpu_percent=12.5,memory_bytes=2097152,",
+		",restarts_total=4,",
+		" 1700000000000000005\n",
+	} {
+		if !strings.Contains(line, want) {
+			t.Errorf("missing %q in\n%s", want, line)
+		}
+	}
+}
+
+func TestInfluxSinkPosts(t *testing.T) {
+	var got, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got, auth = string(b), r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sink, err := ParseSink(strings.Replace(srv.URL, "http://", "influx://", 1) + "?bucket=b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.(*InfluxSink).Token = "secret"
+	if err := sink.Send(context.Background(), Points(sample(), "box", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "docky_container,name=api,") || auth != "Token secret" {
+		t.Errorf("body %q auth %q", got, auth)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bucket not found", http.StatusNotFound)
+	}))
+	defer failing.Close()
+	if err := (&InfluxSink{URL: failing.URL}).Send(context.Background(), Points(sample(), "box", time.Now())); err == nil || !strings.Contains(err.Error(), "bucket not found") {
+		t.Errorf("expected status error, got %v", err)
+	}
+}
+
+func TestGraphiteSinkWritesPlaintext(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	lines := make(chan string, 64)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		sc := bufio.NewScanner(conn)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+	}()
+
+	sink := &GraphiteSink{Addr: ln.Addr().String(), Prefix: "docky"}
+	defer sink.Close()
+	if err := sink.Send(context.Background(), Points(sample(), "my.box", time.Unix(1700000000, 0))); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-lines; got != "docky.my_box.api.cpu_percent 12.5 1700000000" {
+		t.Errorf("first line = %q", got)
+	}
+}
+
+func TestStatsDSinkSendsGauges(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	sink, err := ParseSink("statsd://" + pc.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Send(context.Background(), Points(sample(), "box", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	pc.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 2048)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkt := string(buf[:n])
+	if !strings.HasPrefix(pkt, "docky.box.api.cpu_percent:12.5|g\n") || strings.HasSuffix(pkt, "\n") {
+		t.Errorf("packet = %q", pkt)
+	}
+}
+
+func TestParseSinkRejectsUnknown(t *testing.T) {
+	for _, spec := range []string{"kafka://host:9092", "graphite://", "::"} {
+		if _, err := ParseSink(spec); err == nil {
+			t.Errorf("%q: expected error", spec)
+		}
+	}
+}
+
+type flakySink struct {
+	mu       sync.Mutex
+	failures int
+	batches  [][]Point
+	sent     chan struct{}
+}
+
+func (s *flakySink) Name() string { return "flaky" }
+
+func (s *flakySink) Send(ctx context.Context, points []Point) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("down")
+	}
+	s.batches = append(s.batches, points)
+	s.sent <- struct{}{}
+	return nil
+}
+
+func testPushOptions() PushOptions {
+	return PushOptions{BatchSize: 2, MaxBuffer: 3, MinBackoff: 5 * time.Millisecond, MaxBackoff: 20 * time.Millisecond, Timeout: time.Second}
+}
+
+func TestPusherRetriesAndBatches(t *testing.T) {
+	sink := &flakySink{failures: 2, sent: make(chan struct{}, 16)}
+	p := NewPusher(sink, "box", testPushOptions(), nil)
+	defer p.Close()
+
+	containers := append(sample(), sample()...)
+	containers[1].Names = []string{"/worker"}
+	p.Consume(context.Background(), containers)
+	<-sink.sent
+	p.Consume(context.Background(), sample())
+	<-sink.sent
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if len(sink.batches) != 2 || len(sink.batches[0]) != 2 || len(sink.batches[1]) != 1 {
+		t.Fatalf("batches = %v", sink.batches)
+	}
+	if sink.batches[0][1].Tag("name") != "worker" {
+		t.Errorf("points out of order: %v", sink.batches[0])
+	}
+}
+
+func TestPusherRetriesWhileRefreshesKeepComing(t *testing.T) {
+	sink := &flakySink{failures: 1, sent: make(chan struct{}, 1024)}
+	opts := testPushOptions()
+	opts.MinBackoff, opts.MaxBackoff = 50*time.Millisecond, 50*time.Millisecond
+	p := NewPusher(sink, "box", opts, nil)
+	defer p.Close()
+
+	// refreshes arrive far more often than the backoff; each one used to
+	// restart the retry timer so the sink was never tried again
+	tick := time.NewTicker(5 * time.Millisecond)
+	defer tick.Stop()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case <-sink.sent:
+			return
+		case <-tick.C:
+			p.Consume(context.Background(), sample())
+		case <-deadline:
+			t.Fatal("no delivery after the sink recovered")
+		}
+	}
+}
+
+func TestPusherBoundsBufferWhileDown(t *testing.T) {
+	sink := &flakySink{failures: 1 << 30, sent: make(chan struct{}, 16)}
+	opts := testPushOptions()
+	opts.MinBackoff, opts.MaxBackoff = time.Hour, time.Hour
+	p := NewPusher(sink, "box", opts, nil)
+	defer p.Close()
+
+	for range 5 {
+		p.Consume(context.Background(), sample())
+	}
+	waiting, dropped := p.Buffered()
+	if waiting != 3 || dropped != 2 {
+		t.Errorf("waiting %d dropped %d, want 3 and 2", waiting, dropped)
+	}
+}
@@ -0,0 +1,58 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strconv"
+	"time"
+)
+
+// statsdPacket keeps datagrams below a typical path MTU.
+const statsdPacket = 1400
+
+// StatsDSink sends every field as a gauge over UDP, packing as many lines
+// into a datagram as fit. UDP gives no delivery feedback, so only dial and
+// write errors trigger a retry.
+type StatsDSink struct {
+	Addr   string
+	Prefix string
+}
+
+func (s *StatsDSink) Name() string { return "statsd" }
+
+func (s *StatsDSink) Send(ctx context.Context, points []Point) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", s.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetWriteDeadline(deadline)
+	} else {
+		conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	}
+	var pkt bytes.Buffer
+	flush := func() error {
+		if pkt.Len() == 0 {
+			return nil
+		}
+		_, err := conn.Write(bytes.TrimSuffix(pkt.Bytes(), []byte("\n")))
+		pkt.Reset()
+		return err
+	}
+	for _, p := range points {
+		base := metricPath(s.Prefix, p.Tag("host"), p.Tag("name"))
+		for _, f := range p.Fields {
+			line := base + "." + f.Name + ":" + strconv.FormatFloat(f.Value, 'f', -1, 64) + "|g\n"
+			if pkt.Len()+len(line) > statsdPacket {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			pkt.WriteString(line)
+		}
+	}
+	return flush()
+}
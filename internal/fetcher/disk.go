@@ -0,0 +1,193 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+// ErrNoDisk is returned by Disk when the client can't list images and
+// volumes, as when replaying a recording.
+var ErrNoDisk = errors.New("disk usage is not supported by this client")
+
+// anonymousVolume matches the generated names of unnamed volumes, which
+// daemons before 23.0 don't label as anonymous.
+var anonymousVolume = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+type dfImage struct {
+	ID         string   `json:"Id"`
+	RepoTags   []string `json:"RepoTags"`
+	Created    int64    `json:"Created"`
+	Size       int64    `json:"Size"`
+	SharedSize int64    `json:"SharedSize"`
+	Containers int64    `json:"Containers"`
+}
+
+// Disk lists images and volumes with the containers using them, largest
+// first, and totals what each kind of object takes. Sizes come from
+// /system/df, which can be slow; without them the lists are still returned
+// and Err says why.
+func (f *Fetcher) Disk(ctx context.Context) (domain.DiskUsage, error) {
+	dc, ok := f.client.(docker.DiskClient)
+	if !ok {
+		return domain.DiskUsage{}, ErrNoDisk
+	}
+	var images []dfImage
+	if err := dc.ListImages(ctx, &images); err != nil {
+		return domain.DiskUsage{}, err
+	}
+	var volumes struct {
+		Volumes []struct {
+			Name       string            `json:"Name"`
+			Driver     string            `json:"Driver"`
+			Mountpoint string            `json:"Mountpoint"`
+			Labels     map[string]string `json:"Labels"`
+		} `json:"Volumes"`
+	}
+	if err := dc.ListVolumes(ctx, &volumes); err != nil {
+		return domain.DiskUsage{}, err
+	}
+	raw, err := f.client.ListContainers(ctx)
+	if err != nil {
+		return domain.DiskUsage{}, err
+	}
+	byImage, byVolume := map[string][]string{}, map[string][]string{}
+	for _, r := range raw {
+		namesIface, _ := r["Names"].([]interface{})
+		name, _ := r["Id"].(string)
+		if len(namesIface) > 0 {
+			if s, ok := namesIface[0].(string); ok {
+				name = strings.TrimPrefix(s, "/")
+			}
+		}
+		if id, _ := r["ImageID"].(string); id != "" {
+			byImage[id] = append(byImage[id], name)
+		}
+		mounts, _ := r["Mounts"].([]interface{})
+		for _, m := range mounts {
+			mount, _ := m.(map[string]interface{})
+			if t, _ := mount["Type"].(string); t != "volume" {
+				continue
+			}
+			if v, _ := mount["Name"].(string); v != "" {
+				byVolume[v] = append(byVolume[v], name)
+			}
+		}
+	}
+
+	var df struct {
+		LayersSize int64     `json:"LayersSize"`
+		Images     []dfImage `json:"Images"`
+		Containers []struct {
+			SizeRw int64  `json:"SizeRw"`
+			State  string `json:"State"`
+		} `json:"Containers"`
+		Volumes []struct {
+			Name      string `json:"Name"`
+			UsageData struct {
+				Size     int64 `json:"Size"`
+				RefCount int64 `json:"RefCount"`
+			} `json:"UsageData"`
+		} `json:"Volumes"`
+		BuildCache []struct {
+			Size   int64 `json:"Size"`
+			InUse  bool  `json:"InUse"`
+			Shared bool  `json:"Shared"`
+		} `json:"BuildCache"`
+	}
+	var out domain.DiskUsage
+	if err := dc.DiskUsage(ctx, &df); err != nil {
+		out.Err = err.Error()
+	}
+	shared := map[string]int64{}
+	for _, i := range df.Images {
+		shared[i.ID] = i.SharedSize
+	}
+	for _, i := range images {
+		img := domain.Image{ID: i.ID, Created: time.Unix(i.Created, 0), Size: uint64(max(i.Size, 0)), SharedSize: -1, Containers: byImage[i.ID]}
+		if s, ok := shared[i.ID]; ok {
+			img.SharedSize = s
+		}
+		for _, t := range i.RepoTags {
+			if t != "<none>:<none>" {
+				img.Tags = append(img.Tags, t)
+			}
+		}
+		out.Images = append(out.Images, img)
+	}
+	sizes := map[string]int64{}
+	for _, v := range df.Volumes {
+		sizes[v.Name] = v.UsageData.Size
+	}
+	for _, v := range volumes.Volumes {
+		vol := domain.Volume{Name: v.Name, Driver: v.Driver, Mountpoint: v.Mountpoint, Size: -1, Containers: byVolume[v.Name]}
+		_, labelled := v.Labels["com.docker.volume.anonymous"]
+		vol.Anonymous = labelled || anonymousVolume.MatchString(v.Name)
+		if s, ok := sizes[v.Name]; ok {
+			vol.Size = s
+		}
+		out.Volumes = append(out.Volumes, vol)
+	}
+	sort.SliceStable(out.Images, func(i, j int) bool { return out.Images[i].Size > out.Images[j].Size })
+	sort.SliceStable(out.Volumes, func(i, j int) bool {
+		if out.Volumes[i].Size != out.Volumes[j].Size {
+			return out.Volumes[i].Size > out.Volumes[j].Size
+		}
+		return out.Volumes[i].Name < out.Volumes[j].Name
+	})
+	if out.Err != "" {
+		return out, nil
+	}
+
+	// reclaimable space is worked out the way docker system df does
+	imgs := domain.DiskTotal{Kind: "Images", Count: len(df.Images), Size: uint64(max(df.LayersSize, 0))}
+	var used uint64
+	for _, i := range df.Images {
+		if i.Containers > 0 {
+			imgs.Active++
+			if i.Size >= 0 && i.SharedSize >= 0 {
+				used += uint64(i.Size - i.SharedSize)
+			}
+		}
+	}
+	imgs.Reclaimable = imgs.Size - min(used, imgs.Size)
+	containers := domain.DiskTotal{Kind: "Containers", Count: len(df.Containers)}
+	for _, c := range df.Containers {
+		size := uint64(max(c.SizeRw, 0))
+		containers.Size += size
+		if c.State == "running" {
+			containers.Active++
+		} else {
+			containers.Reclaimable += size
+		}
+	}
+	vols := domain.DiskTotal{Kind: "Volumes", Count: len(df.Volumes)}
+	for _, v := range df.Volumes {
+		size := uint64(max(v.UsageData.Size, 0))
+		vols.Size += size
+		if v.UsageData.RefCount > 0 {
+			vols.Active++
+		} else {
+			vols.Reclaimable += size
+		}
+	}
+	cache := domain.DiskTotal{Kind: "Build cache", Count: len(df.BuildCache)}
+	for _, b := range df.BuildCache {
+		size := uint64(max(b.Size, 0))
+		cache.Size += size
+		if b.InUse {
+			cache.Active++
+		}
+		if !b.InUse && !b.Shared {
+			cache.Reclaimable += size
+		}
+	}
+	out.Totals = []domain.DiskTotal{imgs, containers, vols, cache}
+	return out, nil
+}
@@ -2,6 +2,7 @@ package fetcher
 
 import (
 	"context"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -15,7 +16,7 @@ import (
 
 type BaseContainerInfo = model.BaseContainerInfo
 
-type DetailProvider interface{ DetailFields() map[string]string }
+type DetailProvider = domain.DetailProvider
 
 type ContainerInfo struct {
 	Type domain.ContainerType
@@ -24,20 +25,84 @@ type ContainerInfo struct {
 }
 
 type Fetcher struct {
-	client  docker.DockerClient
-	service docker.Service
-	mu      sync.Mutex
-	prev    map[string]StatsSnapshot
-	entries []strategies.StrategyEntry
-	cfg     FetcherConfig
+	client   docker.DockerClient
+	service  docker.Service
+	mu       sync.Mutex
+	prev     map[string]StatsSnapshot
+	restarts map[string]*restartHistory
+	entries  []strategies.StrategyEntry
+	cfg      FetcherConfig
+
+	traefikMu sync.Mutex
+	traefik   traefikState
 }
 
 type FetcherConfig struct {
-	Concurrency int
-	SortByCPU   bool
+	Concurrency   int
+	StormRestarts int
+	StormWindow   time.Duration
+	// Strategies classify containers, first match wins; without any every
+	// container is generic. main passes plugin.Strategies().
+	Strategies []strategies.StrategyEntry
+	// Host is the name published ports are reached at, localhost when empty.
+	Host string
+	// Traefik, when set, adds router status from the Traefik API.
+	Traefik RouterSource
+	// TraefikInterval is how long an answer of the Traefik API is reused.
+	TraefikInterval time.Duration
+}
+
+func defaultConfig() FetcherConfig {
+	return FetcherConfig{Concurrency: 8, StormRestarts: 3, StormWindow: 5 * time.Minute, TraefikInterval: 30 * time.Second}
+}
+
+type statsResponse struct {
+	CPUStats struct {
+		CPUUsage struct {
+			TotalUsage uint64   `json:"total_usage"`
+			Percpu     []uint64 `json:"percpu_usage"`
+		} `json:"cpu_usage"`
+		SystemCPUUsage uint64 `json:"system_cpu_usage"`
+		OnlineCPUs     uint64 `json:"online_cpus"`
+	} `json:"cpu_stats"`
+	MemoryStats struct {
+		Usage uint64 `json:"usage"`
+		Limit uint64 `json:"limit"`
+	} `json:"memory_stats"`
+	Networks map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+	BlkioStats struct {
+		IOServiceBytesRecursive []struct {
+			Op    string `json:"op"`
+			Value uint64 `json:"value"`
+		} `json:"io_service_bytes_recursive"`
+	} `json:"blkio_stats"`
+	PidsStats struct {
+		Current uint64 `json:"current"`
+	} `json:"pids_stats"`
+}
+
+func (v *statsResponse) netIO() (rx, tx uint64) {
+	for _, n := range v.Networks {
+		rx += n.RxBytes
+		tx += n.TxBytes
+	}
+	return rx, tx
 }
 
-func defaultConfig() FetcherConfig { return FetcherConfig{Concurrency: 8, SortByCPU: false} }
+func (v *statsResponse) blockIO() (read, write uint64) {
+	for _, e := range v.BlkioStats.IOServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			read += e.Value
+		case "write":
+			write += e.Value
+		}
+	}
+	return read, write
+}
 
 type StatsSnapshot struct {
 	CPUTotal   uint64
@@ -52,11 +117,24 @@ func NewWithConfig(c docker.DockerClient, cfg FetcherConfig) *Fetcher {
 	if cfg.Concurrency <= 0 {
 		cfg.Concurrency = 4
 	}
-	return &Fetcher{client: c, prev: make(map[string]StatsSnapshot), entries: strategies.Registry(), cfg: cfg}
+	if cfg.StormRestarts <= 0 {
+		cfg.StormRestarts = 3
+	}
+	if cfg.StormWindow <= 0 {
+		cfg.StormWindow = 5 * time.Minute
+	}
+	if cfg.TraefikInterval <= 0 {
+		cfg.TraefikInterval = 30 * time.Second
+	}
+	return &Fetcher{client: c, prev: make(map[string]StatsSnapshot), restarts: make(map[string]*restartHistory), entries: cfg.Strategies, cfg: cfg}
 }
 
 func NewWithService(s docker.Service, raw docker.DockerClient) *Fetcher {
-	f := New(raw)
+	return NewWithServiceConfig(s, raw, defaultConfig())
+}
+
+func NewWithServiceConfig(s docker.Service, raw docker.DockerClient, cfg FetcherConfig) *Fetcher {
+	f := NewWithConfig(raw, cfg)
 	f.service = s
 	return f
 }
@@ -85,32 +163,32 @@ func (f *Fetcher) FetchAll(ctx context.Context) ([]ContainerInfo, error) {
 		image, _ := r["Image"].(string)
 		state, _ := r["State"].(string)
 		status, _ := r["Status"].(string)
+		labels := map[string]string{}
+		if l, ok := r["Labels"].(map[string]interface{}); ok {
+			for k, v := range l {
+				if s, ok := v.(string); ok {
+					labels[k] = s
+				}
+			}
+		}
+		ports := parsePorts(r["Ports"])
 		names := make([]string, 0, len(namesIface))
 		for _, ni := range namesIface {
 			if s, ok := ni.(string); ok {
 				names = append(names, s)
 			}
 		}
+		networks := parseNetworks(r["NetworkSettings"], id, names)
 		wg.Add(1)
-		go func(id string, names []string, image string, state string, status string, rawContainer map[string]interface{}) {
+		go func(id string, names []string, image string, state string, status string, labels map[string]string, ports []domain.Port, rawContainer map[string]interface{}) {
 			defer wg.Done()
 			sem <- struct{}{}
 			defer func() { <-sem }()
-			var v struct {
-				CPUStats struct {
-					CPUUsage struct {
-						TotalUsage uint64   `json:"total_usage"`
-						Percpu     []uint64 `json:"percpu_usage"`
-					} `json:"cpu_usage"`
-					SystemCPUUsage uint64 `json:"system_cpu_usage"`
-					OnlineCPUs     uint64 `json:"online_cpus"`
-				} `json:"cpu_stats"`
-				MemoryStats struct {
-					Usage uint64 `json:"usage"`
-				} `json:"memory_stats"`
-			}
+			health, lifecycle, inspected := f.inspect(ctx, id)
+			var v statsResponse
 			if err := f.client.ContainerStats(ctx, id, &v); err != nil {
-				ch <- result{info: ContainerInfo{Type: domain.ContainerTypeGeneric, BaseContainerInfo: BaseContainerInfo{ID: id, Names: names, Image: image, Status: state}}, err: nil}
+				url := domain.ServiceURL(labels, ports, 0, f.cfg.Host)
+				ch <- result{info: ContainerInfo{Type: domain.ContainerTypeGeneric, BaseContainerInfo: BaseContainerInfo{ID: id, Names: names, Image: image, Status: state, StatusText: status, Labels: labels, Ports: ports, Networks: networks, URL: url, Health: health, Lifecycle: lifecycle}}, err: nil}
 				return
 			}
 			snap := StatsSnapshot{CPUTotal: v.CPUStats.CPUUsage.TotalUsage, SystemCPU: v.CPUStats.SystemCPUUsage, OnlineCPUs: v.CPUStats.OnlineCPUs, Time: time.Now()}
@@ -129,20 +207,29 @@ func (f *Fetcher) FetchAll(ctx context.Context) ([]ContainerInfo, error) {
 					cpu = (cpuDelta / sysDelta) * float64(snap.OnlineCPUs) * 100.0
 				}
 			}
-			base := model.BaseContainerInfo{ID: id, Names: names, Image: image, CPUPercent: cpu, Mem: v.MemoryStats.Usage / 1024 / 1024, Status: state}
+			rx, tx := v.netIO()
+			blkRead, blkWrite := v.blockIO()
+			base := model.BaseContainerInfo{
+				ID: id, Names: names, Image: image, CPUPercent: cpu,
+				Mem: v.MemoryStats.Usage / 1024 / 1024, MemLimit: v.MemoryStats.Limit / 1024 / 1024,
+				NetRx: rx, NetTx: tx, BlockRead: blkRead, BlockWrite: blkWrite, PIDs: v.PidsStats.Current, Ports: ports, Networks: networks,
+				Status: state, StatusText: status, Labels: labels, Health: health, Lifecycle: lifecycle,
+			}
 			var matchedType domain.ContainerType = domain.ContainerTypeGeneric
 			var specific DetailProvider
+			var webPort uint16
 			for _, entry := range f.entries {
 				if entry.Strategy.Match(image) {
-					matchedType = entry.Type
-					if details, ok := entry.Strategy.Extract(ctx, id, rawContainer, base, f.client).(DetailProvider); ok {
+					matchedType, webPort = entry.Type, entry.WebPort
+					if details, ok := entry.Strategy.Extract(ctx, id, rawContainer, base, inspected).(DetailProvider); ok {
 						specific = details
 					}
 					break
 				}
 			}
+			base.URL = domain.ServiceURL(labels, ports, webPort, f.cfg.Host)
 			ch <- result{info: ContainerInfo{Type: matchedType, BaseContainerInfo: BaseContainerInfo(base), Specific: specific}, err: nil}
-		}(id, names, image, state, status, r)
+		}(id, names, image, state, status, labels, ports, r)
 	}
 	wg.Wait()
 	close(ch)
@@ -153,13 +240,91 @@ func (f *Fetcher) FetchAll(ctx context.Context) ([]ContainerInfo, error) {
 		}
 		out = append(out, r.info)
 	}
-	if f.cfg.SortByCPU {
-		sort.Slice(out, func(i, j int) bool { return out[i].CPUPercent > out[j].CPUPercent })
-	} else {
-		sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Names[0]) < strings.ToLower(out[j].Names[0]) })
+	f.pruneRestarts(out)
+	f.route(ctx, out)
+	// results arrive in completion order; the UI sorts, so only put them in
+	// ID order to keep every refresh the same for consumers
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// parsePorts reads the Ports array of a list entry. The daemon repeats a
+// published port once per address family, so duplicates are dropped.
+func parsePorts(v interface{}) []domain.Port {
+	list, _ := v.([]interface{})
+	var out []domain.Port
+	seen := map[domain.Port]bool{}
+	for _, e := range list {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		p := domain.Port{}
+		p.IP, _ = m["IP"].(string)
+		p.Type, _ = m["Type"].(string)
+		if f, ok := m["PrivatePort"].(float64); ok {
+			p.PrivatePort = uint16(f)
+		}
+		if f, ok := m["PublicPort"].(float64); ok {
+			p.PublicPort = uint16(f)
+		}
+		if p.IP == "::" {
+			p.IP = "0.0.0.0"
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
 	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].PrivatePort != out[j].PrivatePort {
+			return out[i].PrivatePort < out[j].PrivatePort
+		}
+		return out[i].PublicPort < out[j].PublicPort
+	})
+	return out
+}
 
-	return out, nil
+// parseNetworks reads the endpoints of a list entry, sorted by network.
+// Aliases leave out the names the daemon registers for every container, its
+// name and short ID.
+func parseNetworks(v interface{}, id string, names []string) []domain.Endpoint {
+	settings, _ := v.(map[string]interface{})
+	networks, _ := settings["Networks"].(map[string]interface{})
+	var out []domain.Endpoint
+	for name, e := range networks {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ep := domain.Endpoint{Network: name}
+		ep.NetworkID, _ = m["NetworkID"].(string)
+		ep.IPv4, _ = m["IPAddress"].(string)
+		ep.IPv6, _ = m["GlobalIPv6Address"].(string)
+		for _, key := range []string{"Aliases", "DNSNames"} {
+			list, _ := m[key].([]interface{})
+			for _, a := range list {
+				s, _ := a.(string)
+				if s == "" || slices.Contains(ep.Aliases, s) || slices.Contains(names, "/"+s) || strings.HasPrefix(id, s) {
+					continue
+				}
+				ep.Aliases = append(ep.Aliases, s)
+			}
+		}
+		out = append(out, ep)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Network < out[j].Network })
+	return out
+}
+
+// containerName is the first name, falling back to the ID for containers
+// the daemon reports without a name.
+func containerName(c ContainerInfo) string {
+	if len(c.Names) == 0 {
+		return c.ID
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
 }
 
 func (f *Fetcher) DomainContainers(ctx context.Context) ([]domain.Container, error) {
@@ -169,11 +334,16 @@ func (f *Fetcher) DomainContainers(ctx context.Context) ([]domain.Container, err
 	}
 	out := make([]domain.Container, 0, len(legacy))
 	for _, c := range legacy {
-		var details domain.DetailProvider
-		if dp, ok := c.Specific.(DetailProvider); ok {
-			details = dp
-		}
-		out = append(out, domain.Container{ID: c.ID, Names: c.Names, Image: c.Image, Status: c.Status, CPUPercent: c.CPUPercent, MemoryMB: c.Mem, Type: c.Type, Details: details})
+		out = append(out, c.Domain())
 	}
 	return out, nil
 }
+
+func (c ContainerInfo) Domain() domain.Container {
+	return domain.Container{
+		ID: c.ID, Names: c.Names, Image: c.Image, Status: c.Status, StatusText: c.StatusText, Labels: c.Labels,
+		Health: c.Health, Lifecycle: c.Lifecycle, CPUPercent: c.CPUPercent, MemoryMB: c.Mem, MemoryLimitMB: c.MemLimit,
+		NetRxBytes: c.NetRx, NetTxBytes: c.NetTx, BlockReadBytes: c.BlockRead, BlockWriteBytes: c.BlockWrite, PIDs: c.PIDs, Ports: c.Ports,
+		URL: c.URL, Routes: c.Routes, Routing: c.Routing, Networks: c.Networks, Type: c.Type, Details: c.Specific, Alerts: c.Alerts,
+	}
+}
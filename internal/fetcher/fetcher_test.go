@@ -2,11 +2,18 @@ package fetcher
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/wosiu6/docky-go/internal/docker"
 	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher/strategies"
+	"github.com/wosiu6/docky-go/internal/model"
 )
 
 type mockDockerClient struct{}
@@ -59,19 +66,7 @@ func (m *mockDockerClientStats) ContainerInspect(ctx context.Context, id string,
 }
 func (m *mockDockerClientStats) ContainerStats(ctx context.Context, id string, v interface{}) error {
 	m.statsCalls++
-	out := v.(*struct {
-		CPUStats struct {
-			CPUUsage struct {
-				TotalUsage uint64   `json:"total_usage"`
-				Percpu     []uint64 `json:"percpu_usage"`
-			} `json:"cpu_usage"`
-			SystemCPUUsage uint64 `json:"system_cpu_usage"`
-			OnlineCPUs     uint64 `json:"online_cpus"`
-		} `json:"cpu_stats"`
-		MemoryStats struct {
-			Usage uint64 `json:"usage"`
-		} `json:"memory_stats"`
-	})
+	out := v.(*statsResponse)
 	out.CPUStats.CPUUsage.TotalUsage = uint64(100 * m.statsCalls)
 	out.CPUStats.CPUUsage.Percpu = []uint64{1, 2}
 	out.CPUStats.SystemCPUUsage = uint64(1000 * m.statsCalls)
@@ -133,19 +128,7 @@ func (m *mockDockerClientMulti) ContainerInspect(ctx context.Context, id string,
 	return nil
 }
 func (m *mockDockerClientMulti) ContainerStats(ctx context.Context, id string, v interface{}) error {
-	out := v.(*struct {
-		CPUStats struct {
-			CPUUsage struct {
-				TotalUsage uint64   `json:"total_usage"`
-				Percpu     []uint64 `json:"percpu_usage"`
-			} `json:"cpu_usage"`
-			SystemCPUUsage uint64 `json:"system_cpu_usage"`
-			OnlineCPUs     uint64 `json:"online_cpus"`
-		} `json:"cpu_stats"`
-		MemoryStats struct {
-			Usage uint64 `json:"usage"`
-		} `json:"memory_stats"`
-	})
+	out := v.(*statsResponse)
 	if id == "c1" {
 		out.CPUStats.CPUUsage.TotalUsage = 100
 	} else {
@@ -158,34 +141,6 @@ func (m *mockDockerClientMulti) ContainerStats(ctx context.Context, id string, v
 	return nil
 }
 
-func TestFetcher_SortByNameDefault(t *testing.T) {
-	f := New(&mockDockerClientMulti{})
-	items, err := f.FetchAll(context.Background())
-	if err != nil {
-		t.Fatalf("err: %v", err)
-	}
-	if len(items) != 2 {
-		t.Fatalf("expected 2 items")
-	}
-	if items[0].Names[0] != "/A" {
-		t.Errorf("expected first name /A, got %s", items[0].Names[0])
-	}
-}
-
-func TestFetcher_SortByCPU(t *testing.T) {
-	cfg := FetcherConfig{Concurrency: 2, SortByCPU: true}
-	f := NewWithConfig(&mockDockerClientMulti{}, cfg)
-	_, _ = f.FetchAll(context.Background())
-	time.Sleep(5 * time.Millisecond)
-	items, err := f.FetchAll(context.Background())
-	if err != nil {
-		t.Fatalf("err: %v", err)
-	}
-	if items[0].CPUPercent < items[1].CPUPercent {
-		t.Errorf("expected first item to have higher CPU")
-	}
-}
-
 type mockDockerClientStatsError struct{}
 
 func (m *mockDockerClientStatsError) Ping(ctx context.Context) error { return nil }
@@ -225,3 +180,545 @@ func TestFetcher_ConfigConcurrencyFallback(t *testing.T) {
 		t.Errorf("expected concurrency fallback >0, got %d", f.cfg.Concurrency)
 	}
 }
+
+type mockDockerClientHealth struct{ inspect string }
+
+func (m *mockDockerClientHealth) Ping(ctx context.Context) error { return nil }
+func (m *mockDockerClientHealth) ListContainers(ctx context.Context) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{
+		{"Id": "h1", "Names": []interface{}{"/api"}, "Image": "unknown", "State": "running", "Status": "Up 3 hours (unhealthy)"},
+	}, nil
+}
+func (m *mockDockerClientHealth) GetHttpClient() *http.Client { return nil }
+func (m *mockDockerClientHealth) GetUrl() string              { return "mock" }
+func (m *mockDockerClientHealth) ContainerInspect(ctx context.Context, id string, v interface{}) error {
+	return json.Unmarshal([]byte(m.inspect), v)
+}
+func (m *mockDockerClientHealth) ContainerStats(ctx context.Context, id string, v interface{}) error {
+	return nil
+}
+
+type mockDockerClientInspectCount struct {
+	mockDockerClient
+	inspects int
+}
+
+func (m *mockDockerClientInspectCount) ContainerInspect(ctx context.Context, id string, v interface{}) error {
+	m.inspects++
+	return json.Unmarshal([]byte(`{"RestartCount":2,"Config":{"Env":["POSTGRES_DB=shop"]}}`), v)
+}
+
+func TestFetcher_InspectsOncePerContainer(t *testing.T) {
+	m := &mockDockerClientInspectCount{}
+	f := NewWithConfig(m, FetcherConfig{Strategies: []strategies.StrategyEntry{{Type: domain.ContainerTypePostgreSQL, Strategy: &strategies.PostgreSqlStrategy{}}}})
+	containers, err := f.DomainContainers(context.Background())
+	if err != nil || len(containers) != 1 {
+		t.Fatalf("containers = %v, %v", containers, err)
+	}
+	if m.inspects != 1 {
+		t.Errorf("inspected %d times, want 1", m.inspects)
+	}
+	c := containers[0]
+	if db, _ := domain.LookupField(c.Details.DetailFields(), "Database"); db.Text != "shop" || c.Lifecycle.RestartCount != 2 {
+		t.Errorf("strategy or lifecycle lost the inspect payload: %+v, %+v", db, c.Lifecycle)
+	}
+}
+
+func TestFetcher_HealthParsed(t *testing.T) {
+	m := &mockDockerClientHealth{inspect: `{"State":{"Status":"running","Health":{"Status":"unhealthy","FailingStreak":3,"Log":[
+		{"ExitCode":0,"Output":"ok"},{"ExitCode":1,"Output":"a"},{"ExitCode":1,"Output":"b"},
+		{"ExitCode":1,"Output":"c"},{"ExitCode":1,"Output":"d"},{"ExitCode":1,"Output":"  timeout\n"}]}}}`}
+	f := New(m)
+	containers, err := f.DomainContainers(context.Background())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	c := containers[0]
+	if c.StatusText != "Up 3 hours (unhealthy)" {
+		t.Errorf("status text not kept: %q", c.StatusText)
+	}
+	if c.Health == nil || c.Health.Status != domain.HealthUnhealthy || c.Health.FailingStreak != 3 {
+		t.Fatalf("unexpected health: %#v", c.Health)
+	}
+	if len(c.Health.Log) != maxHealthLog {
+		t.Fatalf("expected %d probes, got %d", maxHealthLog, len(c.Health.Log))
+	}
+	if last := c.Health.Log[len(c.Health.Log)-1]; last.Output != "timeout" || last.ExitCode != 1 {
+		t.Errorf("unexpected last probe: %#v", last)
+	}
+	if got := domain.EffectiveState(c.Status, c.Health); got != domain.StateUnhealthy {
+		t.Errorf("expected unhealthy effective state, got %s", got)
+	}
+}
+
+func TestFetcher_NoHealthcheck(t *testing.T) {
+	f := New(&mockDockerClientHealth{inspect: `{"State":{"Status":"running"}}`})
+	items, err := f.FetchAll(context.Background())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if items[0].Health != nil {
+		t.Errorf("expected no health, got %#v", items[0].Health)
+	}
+	if got := domain.EffectiveState(items[0].Status, items[0].Health); got != "running" {
+		t.Errorf("expected running, got %s", got)
+	}
+}
+
+func TestFetcher_LifecycleParsed(t *testing.T) {
+	f := New(&mockDockerClientHealth{inspect: `{"RestartCount":2,"State":{"Status":"exited","ExitCode":137,"OOMKilled":true,
+		"StartedAt":"2025-01-01T10:00:00Z","FinishedAt":"2025-01-01T11:00:00Z"}}`})
+	items, err := f.FetchAll(context.Background())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	l := items[0].Lifecycle
+	if l.RestartCount != 2 || l.ExitCode != 137 || !l.OOMKilled {
+		t.Fatalf("unexpected lifecycle: %#v", l)
+	}
+	if l.ExitReason() != "exit 137 (OOM killed)" {
+		t.Errorf("unexpected exit reason %q", l.ExitReason())
+	}
+	if l.RestartStorm || l.RecentRestarts != 0 {
+		t.Errorf("first observation should not count restarts: %#v", l)
+	}
+}
+
+func TestFetcher_RestartStorm(t *testing.T) {
+	m := &mockDockerClientHealth{}
+	f := NewWithConfig(m, FetcherConfig{StormRestarts: 3, StormWindow: time.Minute})
+	fetch := func(count int, started string) domain.Lifecycle {
+		m.inspect = fmt.Sprintf(`{"RestartCount":%d,"State":{"Status":"running","StartedAt":%q}}`, count, started)
+		items, err := f.FetchAll(context.Background())
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		return items[0].Lifecycle
+	}
+	fetch(0, "2025-01-01T10:00:00Z")
+	if l := fetch(1, "2025-01-01T10:01:00Z"); l.RecentRestarts != 1 || l.RestartStorm {
+		t.Fatalf("expected one restart, got %#v", l)
+	}
+	// A manual restart only changes StartedAt.
+	if l := fetch(1, "2025-01-01T10:02:00Z"); l.RecentRestarts != 2 || l.RestartStorm {
+		t.Fatalf("expected two restarts, got %#v", l)
+	}
+	if l := fetch(2, "2025-01-01T10:03:00Z"); !l.RestartStorm {
+		t.Fatalf("expected restart storm, got %#v", l)
+	}
+	if l := fetch(2, "2025-01-01T10:03:00Z"); l.RecentRestarts != 3 {
+		t.Fatalf("unchanged container should keep its history, got %#v", l)
+	}
+}
+
+func TestFetcher_RestartHistoryExpires(t *testing.T) {
+	f := NewWithConfig(&mockDockerClientHealth{}, FetcherConfig{StormRestarts: 2, StormWindow: time.Minute})
+	now := time.Now()
+	l := domain.Lifecycle{RestartCount: 0}
+	f.trackRestarts("x", &l, now.Add(-3*time.Minute))
+	l = domain.Lifecycle{RestartCount: 2}
+	f.trackRestarts("x", &l, now.Add(-2*time.Minute))
+	if !l.RestartStorm {
+		t.Fatalf("expected storm, got %#v", l)
+	}
+	l = domain.Lifecycle{RestartCount: 2}
+	f.trackRestarts("x", &l, now)
+	if l.RestartStorm || l.RecentRestarts != 0 {
+		t.Fatalf("expected restarts outside the window to expire, got %#v", l)
+	}
+}
+
+type mockDockerClientIO struct{}
+
+func (m *mockDockerClientIO) Ping(ctx context.Context) error { return nil }
+func (m *mockDockerClientIO) ListContainers(ctx context.Context) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{{"Id": "io", "Names": []interface{}{"/io"}, "Image": "unknown", "State": "running",
+		"Labels": map[string]interface{}{"com.docker.compose.project": "shop"}}}, nil
+}
+func (m *mockDockerClientIO) GetHttpClient() *http.Client { return nil }
+func (m *mockDockerClientIO) GetUrl() string              { return "mock" }
+func (m *mockDockerClientIO) ContainerInspect(ctx context.Context, id string, v interface{}) error {
+	return nil
+}
+func (m *mockDockerClientIO) ContainerStats(ctx context.Context, id string, v interface{}) error {
+	return json.Unmarshal([]byte(`{
+		"memory_stats":{"usage":1048576,"limit":4194304},
+		"networks":{"eth0":{"rx_bytes":10,"tx_bytes":20},"eth1":{"rx_bytes":1,"tx_bytes":2}},
+		"blkio_stats":{"io_service_bytes_recursive":[{"op":"Read","value":5},{"op":"Write","value":6},{"op":"read","value":1}]},
+		"pids_stats":{"current":9}}`), v)
+}
+
+func TestFetcher_NetworkBlockIOAndLabels(t *testing.T) {
+	containers, err := New(&mockDockerClientIO{}).DomainContainers(context.Background())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	c := containers[0]
+	if c.NetRxBytes != 11 || c.NetTxBytes != 22 {
+		t.Errorf("unexpected net io rx=%d tx=%d", c.NetRxBytes, c.NetTxBytes)
+	}
+	if c.BlockReadBytes != 6 || c.BlockWriteBytes != 6 {
+		t.Errorf("unexpected block io read=%d write=%d", c.BlockReadBytes, c.BlockWriteBytes)
+	}
+	if c.PIDs != 9 || c.MemoryMB != 1 || c.MemoryLimitMB != 4 || c.MemoryPercent() != 25 {
+		t.Errorf("unexpected pids/mem: %#v", c)
+	}
+	if c.ComposeProject() != "shop" {
+		t.Errorf("expected compose project label, got %q", c.ComposeProject())
+	}
+}
+
+type mockDockerClientNames struct{ mockDockerClient }
+
+func (m *mockDockerClientNames) ListContainers(ctx context.Context) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{
+		{"Id": "c3", "Names": []interface{}{"/Web"}, "Image": "nginx", "State": "running"},
+		{"Id": "c2", "Image": "busybox", "State": "created"},
+		{"Id": "c1", "Names": []interface{}{"/web"}, "Image": "nginx", "State": "running"},
+	}, nil
+}
+
+func TestFetcher_FetchAll_OrdersByID(t *testing.T) {
+	f := New(&mockDockerClientNames{})
+	for range 5 {
+		items, err := f.FetchAll(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, it := range items {
+			ids = append(ids, it.ID)
+		}
+		if fmt.Sprint(ids) != "[c1 c2 c3]" {
+			t.Fatalf("order = %v, want [c1 c2 c3]", ids)
+		}
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{"IP": "0.0.0.0", "PrivatePort": float64(5432), "PublicPort": float64(15432), "Type": "tcp"},
+		map[string]interface{}{"IP": "::", "PrivatePort": float64(5432), "PublicPort": float64(15432), "Type": "tcp"},
+		map[string]interface{}{"PrivatePort": float64(80), "Type": "tcp"},
+		map[string]interface{}{"IP": "127.0.0.1", "PrivatePort": float64(9000), "PublicPort": float64(9000), "Type": "udp"},
+	}
+	var got []string
+	for _, p := range parsePorts(raw) {
+		got = append(got, p.String())
+	}
+	want := []string{"80/tcp", "15432->5432/tcp", "127.0.0.1:9000->9000/udp"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if parsePorts(nil) != nil {
+		t.Fatal("expected no ports for a missing list")
+	}
+}
+
+type webStrategy struct{}
+
+func (webStrategy) Match(image string) bool { return image == "grafana/grafana" }
+func (webStrategy) Extract(context.Context, string, map[string]interface{}, model.BaseContainerInfo, interface{}) interface{} {
+	return nil
+}
+
+type mockDockerClientWeb struct{ mockDockerClient }
+
+func (m *mockDockerClientWeb) ListContainers(ctx context.Context) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{
+		{"Id": "g", "Names": []interface{}{"/grafana"}, "Image": "grafana/grafana", "State": "running",
+			"Ports": []interface{}{map[string]interface{}{"IP": "0.0.0.0", "PrivatePort": float64(3000), "PublicPort": float64(3001), "Type": "tcp"}}},
+		{"Id": "w", "Names": []interface{}{"/whoami"}, "Image": "traefik/whoami", "State": "running",
+			"Labels": map[string]interface{}{"traefik.http.routers.who.rule": "Host(`who.example.com`)"}},
+		{"Id": "x", "Names": []interface{}{"/x"}, "Image": "busybox", "State": "running",
+			"Ports": []interface{}{map[string]interface{}{"PrivatePort": float64(3000), "PublicPort": float64(3000), "Type": "tcp"}}},
+	}, nil
+}
+
+func TestFetcher_ServiceURLOverTCP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/containers/json" {
+			w.Write([]byte(`[{"Id": "g", "Names": ["/grafana"], "Image": "grafana/grafana", "State": "running",
+				"Ports": [{"IP": "0.0.0.0", "PrivatePort": 3000, "PublicPort": 3001, "Type": "tcp"}]}]`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	t.Setenv("DOCKER_HOST", strings.Replace(srv.URL, "http://", "tcp://", 1))
+	client, err := docker.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := NewWithConfig(client, FetcherConfig{
+		Strategies: []strategies.StrategyEntry{{Type: domain.ContainerTypeGrafana, Strategy: webStrategy{}, WebPort: 3000}},
+		Host:       docker.ServiceHost(),
+	})
+	containers, err := f.DomainContainers(context.Background())
+	if err != nil || len(containers) != 1 {
+		t.Fatalf("containers = %v, %v", containers, err)
+	}
+	if containers[0].URL != "http://127.0.0.1:3001" {
+		t.Errorf("url = %q, want the tcp daemon's host", containers[0].URL)
+	}
+}
+
+func TestFetcher_ServiceURL(t *testing.T) {
+	f := NewWithConfig(&mockDockerClientWeb{}, FetcherConfig{
+		Strategies: []strategies.StrategyEntry{{Type: domain.ContainerTypeGrafana, Strategy: webStrategy{}, WebPort: 3000}},
+		Host:       "docker.lan",
+	})
+	containers, err := f.DomainContainers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, c := range containers {
+		got[c.Name()] = c.URL
+	}
+	want := map[string]string{"grafana": "http://docker.lan:3001", "whoami": "http://who.example.com", "x": ""}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+type fakeRouters struct {
+	err   error
+	calls *int
+}
+
+func (f fakeRouters) Routers(context.Context) ([]domain.Route, error) {
+	if f.calls != nil {
+		*f.calls++
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	file := domain.Route{Router: "legacy", Provider: "file", Status: "enabled"}
+	file.SetRule("Host(`old.example.com`)")
+	return []domain.Route{
+		{Router: "who", Provider: "docker", Status: "warning", Errors: []string{"no TLS certificate"}},
+		{Router: "api", Provider: "internal", Status: "enabled"},
+		file,
+	}, nil
+}
+
+func (f fakeRouters) Overview(context.Context) (domain.TraefikOverview, error) {
+	return domain.TraefikOverview{Routers: domain.TraefikCounts{Total: 3, Warnings: 1}}, nil
+}
+
+type traefikStrategy struct{}
+
+func (traefikStrategy) Match(image string) bool { return image == "traefik" }
+func (traefikStrategy) Extract(context.Context, string, map[string]interface{}, model.BaseContainerInfo, interface{}) interface{} {
+	return nil
+}
+
+type mockDockerClientRoutes struct{ mockDockerClientWeb }
+
+func (m *mockDockerClientRoutes) ListContainers(ctx context.Context) ([]map[string]interface{}, error) {
+	list, _ := m.mockDockerClientWeb.ListContainers(ctx)
+	return append(list,
+		map[string]interface{}{"Id": "t", "Names": []interface{}{"/proxy"}, "Image": "traefik", "State": "running"},
+		// a router the API doesn't know, on a container sorting before whoami
+		map[string]interface{}{"Id": "a", "Names": []interface{}{"/app"}, "Image": "nginx", "State": "running",
+			"Labels": map[string]interface{}{"traefik.http.routers.app.rule": "Host(`app.example.com`)"}},
+	), nil
+}
+
+func TestFetcher_Routes(t *testing.T) {
+	cfg := FetcherConfig{Strategies: []strategies.StrategyEntry{{Type: domain.ContainerTypeTraefik, Strategy: traefikStrategy{}}}, Traefik: fakeRouters{}}
+	containers, err := NewWithConfig(&mockDockerClientRoutes{}, cfg).DomainContainers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]domain.Container{}
+	for _, c := range containers {
+		byName[c.Name()] = c
+	}
+	who := byName["whoami"].Routes
+	if len(who) != 1 || who[0].Container != "whoami" || who[0].Status != "warning" || fmt.Sprint(domain.RouteHosts(who)) != "[who.example.com]" {
+		t.Fatalf("whoami routes = %+v", who)
+	}
+	if byName["grafana"].Routes != nil || byName["grafana"].Routing != nil {
+		t.Error("grafana should have no routes")
+	}
+	table := byName["proxy"].Routing
+	if table == nil || table.Overview == nil || table.Overview.Routers.Total != 3 {
+		t.Fatalf("proxy routing = %+v", table)
+	}
+	var got []string
+	for _, r := range table.Routes {
+		got = append(got, r.Router+"@"+r.Provider+">"+r.Container)
+	}
+	if fmt.Sprint(got) != "[legacy@file> app@docker>app who@docker>whoami]" {
+		t.Errorf("table = %v", got)
+	}
+
+	cfg.Traefik = fakeRouters{err: fmt.Errorf("connection refused")}
+	containers, _ = NewWithConfig(&mockDockerClientRoutes{}, cfg).DomainContainers(context.Background())
+	for _, c := range containers {
+		if c.Name() == "proxy" && (c.Routing == nil || c.Routing.Err != "connection refused" || len(c.Routing.Routes) != 2) {
+			t.Errorf("label routes should survive an API error: %+v", c.Routing)
+		}
+	}
+}
+
+func TestFetcher_TraefikAPICached(t *testing.T) {
+	calls := 0
+	cfg := FetcherConfig{Strategies: []strategies.StrategyEntry{{Type: domain.ContainerTypeTraefik, Strategy: traefikStrategy{}}}, Traefik: fakeRouters{calls: &calls}}
+	f := NewWithConfig(&mockDockerClientRoutes{}, cfg)
+	for range 3 {
+		if _, err := f.DomainContainers(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Traefik API queried %d times, want 1", calls)
+	}
+
+	// without a Traefik container there is nothing to ask
+	calls = 0
+	cfg.Strategies = nil
+	if _, err := NewWithConfig(&mockDockerClientRoutes{}, cfg).DomainContainers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 0 {
+		t.Errorf("Traefik API queried %d times without a Traefik container", calls)
+	}
+}
+
+type mockDockerClientNetworks struct{ mockDockerClient }
+
+func (m *mockDockerClientNetworks) ListContainers(ctx context.Context) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{
+		{"Id": "0123456789abcdef", "Names": []interface{}{"/app-web-1"}, "Image": "nginx", "State": "running",
+			"NetworkSettings": map[string]interface{}{"Networks": map[string]interface{}{
+				"app_default": map[string]interface{}{"NetworkID": "n1", "IPAddress": "172.20.0.2",
+					"Aliases": []interface{}{"app-web-1", "web"}, "DNSNames": []interface{}{"app-web-1", "web", "0123456789ab"}},
+				"bridge": map[string]interface{}{"NetworkID": "n0", "IPAddress": "172.17.0.2"},
+			}}},
+	}, nil
+}
+
+func (m *mockDockerClientNetworks) ListNetworks(ctx context.Context, dest any) error {
+	return json.Unmarshal([]byte(`[
+		{"Id": "n0", "Name": "bridge", "Driver": "bridge"},
+		{"Id": "n2", "Name": "zeta", "Driver": "overlay", "Scope": "swarm", "Internal": true},
+		{"Id": "n1", "Name": "app_default", "Driver": "bridge", "Scope": "local", "IPAM": {"Config": [{"Subnet": "172.20.0.0/16"}]}}
+	]`), dest)
+}
+
+func TestFetcher_Networks(t *testing.T) {
+	f := New(&mockDockerClientNetworks{})
+	containers, err := f.DomainContainers(context.Background())
+	if err != nil || len(containers) != 1 {
+		t.Fatalf("containers = %v, %v", containers, err)
+	}
+	eps := containers[0].Networks
+	if len(eps) != 2 || eps[0].Network != "app_default" || eps[0].IPv4 != "172.20.0.2" || fmt.Sprint(eps[0].Aliases) != "[web]" {
+		t.Fatalf("endpoints = %+v", eps)
+	}
+	networks, err := f.Networks(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(networks) != 2 || networks[0].Name != "app_default" || fmt.Sprint(networks[0].Subnets) != "[172.20.0.0/16]" || !networks[1].Internal {
+		t.Fatalf("networks = %+v", networks)
+	}
+	if _, err := New(&mockDockerClient{}).Networks(context.Background()); err != ErrNoNetworks {
+		t.Errorf("expected ErrNoNetworks, got %v", err)
+	}
+}
+
+type mockDockerClientDisk struct {
+	mockDockerClient
+	dfErr error
+}
+
+func (m *mockDockerClientDisk) ListContainers(ctx context.Context) ([]map[string]interface{}, error) {
+	return []map[string]interface{}{
+		{"Id": "a", "Names": []interface{}{"/db"}, "Image": "postgres", "ImageID": "sha256:pg", "State": "exited",
+			"Mounts": []interface{}{map[string]interface{}{"Type": "volume", "Name": "pgdata"}, map[string]interface{}{"Type": "bind", "Source": "/srv"}}},
+	}, nil
+}
+
+func (m *mockDockerClientDisk) ListImages(ctx context.Context, dest any) error {
+	return json.Unmarshal([]byte(`[
+		{"Id": "sha256:old", "RepoTags": ["<none>:<none>"], "Created": 1700000000, "Size": 300, "SharedSize": -1},
+		{"Id": "sha256:pg", "RepoTags": ["postgres:16"], "Created": 1710000000, "Size": 400, "SharedSize": -1}
+	]`), dest)
+}
+
+func (m *mockDockerClientDisk) ListVolumes(ctx context.Context, dest any) error {
+	return json.Unmarshal([]byte(`{"Volumes": [
+		{"Name": "pgdata", "Driver": "local", "Mountpoint": "/var/lib/docker/volumes/pgdata/_data"},
+		{"Name": "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", "Driver": "local"},
+		{"Name": "cache", "Driver": "local", "Labels": {"com.docker.volume.anonymous": ""}},
+		{"Name": "named", "Driver": "local"}
+	]}`), dest)
+}
+
+func (m *mockDockerClientDisk) DiskUsage(ctx context.Context, dest any) error {
+	if m.dfErr != nil {
+		return m.dfErr
+	}
+	return json.Unmarshal([]byte(`{
+		"LayersSize": 600,
+		"Images": [{"Id": "sha256:old", "Size": 300, "SharedSize": 100, "Containers": 0}, {"Id": "sha256:pg", "Size": 400, "SharedSize": 100, "Containers": 1}],
+		"Containers": [{"SizeRw": 10, "State": "exited"}],
+		"Volumes": [
+			{"Name": "pgdata", "UsageData": {"Size": 5000, "RefCount": 1}},
+			{"Name": "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", "UsageData": {"Size": 70, "RefCount": 0}},
+			{"Name": "cache", "UsageData": {"Size": 30, "RefCount": 0}},
+			{"Name": "named", "UsageData": {"Size": 1000, "RefCount": 0}}
+		],
+		"BuildCache": [{"Size": 50, "InUse": false, "Shared": false}, {"Size": 20, "InUse": true}]
+	}`), dest)
+}
+
+func TestFetcher_Disk(t *testing.T) {
+	d, err := New(&mockDockerClientDisk{}).Disk(context.Background())
+	if err != nil || d.Err != "" {
+		t.Fatal(err, d.Err)
+	}
+	if len(d.Images) != 2 || d.Images[0].Tags[0] != "postgres:16" || fmt.Sprint(d.Images[0].Containers) != "[db]" || !d.Images[1].Dangling() {
+		t.Fatalf("images = %+v", d.Images)
+	}
+	if n, size := d.DanglingImages(); n != 1 || size != 200 {
+		t.Errorf("dangling = %d, %d", n, size)
+	}
+	var vols []string
+	for _, v := range d.Volumes {
+		vols = append(vols, fmt.Sprintf("%.8s:%d:%v:%v", v.Name, v.Size, v.Anonymous, v.Containers))
+	}
+	if fmt.Sprint(vols) != "[pgdata:5000:false:[db] named:1000:false:[] 01234567:70:true:[] cache:30:true:[]]" {
+		t.Errorf("volumes = %v", vols)
+	}
+	if n, size := d.UnusedVolumes(); n != 2 || size != 100 {
+		t.Errorf("unused volumes = %d, %d", n, size)
+	}
+	var prunable []string
+	for _, v := range d.PrunableVolumes() {
+		prunable = append(prunable, fmt.Sprintf("%.8s", v.Name))
+	}
+	if fmt.Sprint(prunable) != "[01234567 cache]" {
+		t.Errorf("prunable volumes = %v", prunable)
+	}
+	var totals []string
+	for _, tot := range d.Totals {
+		totals = append(totals, fmt.Sprintf("%s %d/%d %d %d", tot.Kind, tot.Active, tot.Count, tot.Size, tot.Reclaimable))
+	}
+	want := "[Images 1/2 600 300 Containers 0/1 10 10 Volumes 1/4 6100 1100 Build cache 1/2 70 50]"
+	if fmt.Sprint(totals) != want || d.Reclaimable() != 1460 {
+		t.Errorf("totals = %v, reclaimable %d", totals, d.Reclaimable())
+	}
+
+	d, err = New(&mockDockerClientDisk{dfErr: fmt.Errorf("timeout")}).Disk(context.Background())
+	if err != nil || d.Err != "timeout" || len(d.Images) != 2 || d.Volumes[0].Size != -1 || d.Totals != nil {
+		t.Errorf("without df: %+v, %v", d, err)
+	}
+	if _, err := New(&mockDockerClient{}).Disk(context.Background()); err != ErrNoDisk {
+		t.Errorf("expected ErrNoDisk, got %v", err)
+	}
+}
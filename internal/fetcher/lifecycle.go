@@ -0,0 +1,143 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+type inspectState struct {
+	RestartCount int `json:"RestartCount"`
+	State        struct {
+		Status     string    `json:"Status"`
+		ExitCode   int       `json:"ExitCode"`
+		OOMKilled  bool      `json:"OOMKilled"`
+		Error      string    `json:"Error"`
+		StartedAt  time.Time `json:"StartedAt"`
+		FinishedAt time.Time `json:"FinishedAt"`
+		Health     *struct {
+			Status        string `json:"Status"`
+			FailingStreak int    `json:"FailingStreak"`
+			Log           []struct {
+				Start    time.Time `json:"Start"`
+				End      time.Time `json:"End"`
+				ExitCode int       `json:"ExitCode"`
+				Output   string    `json:"Output"`
+			} `json:"Log"`
+		} `json:"Health"`
+	} `json:"State"`
+}
+
+// maxHealthLog caps how many probe results are kept per container; the
+// daemon itself keeps the last five.
+const maxHealthLog = 5
+
+// restartHistory remembers what a container looked like on the previous tick
+// so restarts can be counted even when the daemon does not bump RestartCount
+// (a manual `docker restart` only changes StartedAt).
+type restartHistory struct {
+	count     int
+	startedAt time.Time
+	events    []time.Time
+}
+
+// inspected answers the strategies' ContainerInspect for the container
+// being fetched from the payload the fetcher already read, so every
+// container is inspected once per refresh.
+type inspected struct {
+	docker.DockerClient
+	id  string
+	raw json.RawMessage
+	err error
+}
+
+func (c inspected) ContainerInspect(ctx context.Context, id string, v interface{}) error {
+	switch {
+	case id != c.id:
+		return c.DockerClient.ContainerInspect(ctx, id, v)
+	case c.err != nil:
+		return c.err
+	case len(c.raw) == 0:
+		return nil
+	}
+	return json.Unmarshal(c.raw, v)
+}
+
+// inspect reads the container's health and lifecycle and returns the
+// payload for the strategies.
+func (f *Fetcher) inspect(ctx context.Context, id string) (*domain.Health, domain.Lifecycle, inspected) {
+	in := inspected{DockerClient: f.client, id: id}
+	in.err = f.client.ContainerInspect(ctx, id, &in.raw)
+	var v inspectState
+	if in.ContainerInspect(ctx, id, &v) != nil {
+		return nil, domain.Lifecycle{}, in
+	}
+	l := domain.Lifecycle{
+		RestartCount: v.RestartCount,
+		ExitCode:     v.State.ExitCode,
+		OOMKilled:    v.State.OOMKilled,
+		Error:        v.State.Error,
+		StartedAt:    v.State.StartedAt,
+		FinishedAt:   v.State.FinishedAt,
+	}
+	f.trackRestarts(id, &l, time.Now())
+	if v.State.Health == nil {
+		return nil, l, in
+	}
+	h := &domain.Health{Status: domain.HealthStatus(strings.ToLower(v.State.Health.Status)), FailingStreak: v.State.Health.FailingStreak}
+	log := v.State.Health.Log
+	if len(log) > maxHealthLog {
+		log = log[len(log)-maxHealthLog:]
+	}
+	for _, p := range log {
+		h.Log = append(h.Log, domain.HealthProbe{Start: p.Start, End: p.End, ExitCode: p.ExitCode, Output: strings.TrimSpace(p.Output)})
+	}
+	return h, l, in
+}
+
+func (f *Fetcher) trackRestarts(id string, l *domain.Lifecycle, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h, ok := f.restarts[id]
+	if !ok {
+		f.restarts[id] = &restartHistory{count: l.RestartCount, startedAt: l.StartedAt}
+		return
+	}
+	n := l.RestartCount - h.count
+	if n <= 0 && !l.StartedAt.IsZero() && !l.StartedAt.Equal(h.startedAt) {
+		n = 1
+	}
+	for range max(n, 0) {
+		h.events = append(h.events, now)
+	}
+	h.count = l.RestartCount
+	h.startedAt = l.StartedAt
+	cutoff := now.Add(-f.cfg.StormWindow)
+	kept := h.events[:0]
+	for _, t := range h.events {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	h.events = kept
+	l.RecentRestarts = len(h.events)
+	l.RestartStorm = len(h.events) >= f.cfg.StormRestarts
+}
+
+func (f *Fetcher) pruneRestarts(seen []ContainerInfo) {
+	ids := make(map[string]struct{}, len(seen))
+	for _, c := range seen {
+		ids[c.ID] = struct{}{}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for id := range f.restarts {
+		if _, ok := ids[id]; !ok {
+			delete(f.restarts, id)
+		}
+	}
+}
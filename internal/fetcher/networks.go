@@ -0,0 +1,56 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+// ErrNoNetworks is returned by Networks when the client can't list
+// networks, as when replaying a recording.
+var ErrNoNetworks = errors.New("listing networks is not supported by this client")
+
+// Networks lists the user-defined networks, leaving out the bridge, host and
+// none networks every daemon has. Members are left empty: the daemon's list
+// doesn't include them, they come from each container's endpoints instead,
+// see domain.AttachMembers.
+func (f *Fetcher) Networks(ctx context.Context) ([]domain.Network, error) {
+	nc, ok := f.client.(docker.NetworkClient)
+	if !ok {
+		return nil, ErrNoNetworks
+	}
+	var raw []struct {
+		ID       string `json:"Id"`
+		Name     string `json:"Name"`
+		Driver   string `json:"Driver"`
+		Scope    string `json:"Scope"`
+		Internal bool   `json:"Internal"`
+		IPAM     struct {
+			Config []struct {
+				Subnet string `json:"Subnet"`
+			} `json:"Config"`
+		} `json:"IPAM"`
+	}
+	if err := nc.ListNetworks(ctx, &raw); err != nil {
+		return nil, err
+	}
+	var out []domain.Network
+	for _, n := range raw {
+		switch n.Name {
+		case "bridge", "host", "none":
+			continue
+		}
+		net := domain.Network{ID: n.ID, Name: n.Name, Driver: n.Driver, Scope: n.Scope, Internal: n.Internal}
+		for _, c := range n.IPAM.Config {
+			if c.Subnet != "" {
+				net.Subnets = append(net.Subnets, c.Subnet)
+			}
+		}
+		out = append(out, net)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
@@ -0,0 +1,111 @@
+package fetcher
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+// RouterSource reports the routers a Traefik instance has loaded;
+// traefik.Client implements it.
+type RouterSource interface {
+	Routers(ctx context.Context) ([]domain.Route, error)
+	Overview(ctx context.Context) (domain.TraefikOverview, error)
+}
+
+// traefikState is the last answer of the Traefik API, reused until
+// TraefikInterval has passed so refreshes don't wait on it every tick.
+type traefikState struct {
+	at       time.Time
+	routes   []domain.Route
+	overview *domain.TraefikOverview
+	err      string
+}
+
+// traefikAPI returns the API's routers, without Traefik's internal ones, and
+// its overview, querying it at most once per TraefikInterval.
+func (f *Fetcher) traefikAPI(ctx context.Context) traefikState {
+	f.traefikMu.Lock()
+	defer f.traefikMu.Unlock()
+	if !f.traefik.at.IsZero() && time.Since(f.traefik.at) < f.cfg.TraefikInterval {
+		return f.traefik
+	}
+	st := traefikState{at: time.Now()}
+	if api, err := f.cfg.Traefik.Routers(ctx); err != nil {
+		st.err = err.Error()
+	} else {
+		for _, r := range api {
+			if r.Provider != "internal" {
+				st.routes = append(st.routes, r)
+			}
+		}
+		if o, err := f.cfg.Traefik.Overview(ctx); err == nil {
+			st.overview = &o
+		} else {
+			st.err = err.Error()
+		}
+	}
+	f.traefik = st
+	return st
+}
+
+// route gives every container the routers its labels declare and every
+// Traefik container the whole routing table. With a RouterSource, routes get
+// their status from the API and routers only the API knows about, such as
+// those from the file provider, are added to the table. The API is only
+// asked while a Traefik container is running.
+func (f *Fetcher) route(ctx context.Context, containers []ContainerInfo) {
+	table := &domain.RoutingTable{}
+	hasTraefik := false
+	for i := range containers {
+		c := &containers[i]
+		hasTraefik = hasTraefik || c.Type == domain.ContainerTypeTraefik
+		c.Routes = domain.RoutesFromLabels(c.Labels)
+		for j := range c.Routes {
+			c.Routes[j].Container = containerName(*c)
+		}
+	}
+	if f.cfg.Traefik != nil && hasTraefik {
+		st := f.traefikAPI(ctx)
+		table.Routes = slices.Clone(st.routes)
+		table.Overview, table.Err = st.overview, st.err
+	}
+	byName := make(map[string]int, len(table.Routes))
+	for i, r := range table.Routes {
+		byName[r.Router+"@"+r.Provider] = i
+	}
+	// routes only the labels know about are added after the loop, which
+	// updates API routes in table.Routes by index
+	var labelOnly []domain.Route
+	for i := range containers {
+		c := &containers[i]
+		for j := range c.Routes {
+			r := &c.Routes[j]
+			if k, ok := byName[r.Router+"@"+r.Provider]; ok {
+				api := &table.Routes[k]
+				r.Status, r.Errors = api.Status, api.Errors
+				api.Container = r.Container
+				continue
+			}
+			labelOnly = append(labelOnly, *r)
+		}
+	}
+	table.Routes = append(table.Routes, labelOnly...)
+	if len(table.Routes) == 0 && table.Overview == nil && table.Err == "" {
+		return
+	}
+	slices.SortStableFunc(table.Routes, func(a, b domain.Route) int {
+		if c := strings.Compare(a.Container, b.Container); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Router, b.Router)
+	})
+	for i := range containers {
+		if containers[i].Type == domain.ContainerTypeTraefik {
+			containers[i].Routing = table
+		}
+	}
+}
@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -22,7 +22,7 @@ func (s *GrafanaStrategy) Match(image string) bool {
 }
 
 func (s *GrafanaStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &GrafanaContainerInfo{BaseContainerInfo: base}
+	info := &GrafanaContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 3000)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(ctx context.Context, id string, v interface{}) error
 	})
@@ -34,11 +34,6 @@ func (s *GrafanaStrategy) Extract(ctx context.Context, id string, raw map[string
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -48,25 +43,14 @@ func (s *GrafanaStrategy) Extract(ctx context.Context, id string, raw map[string
 		if v, ok := envMap["GF_VERSION"]; ok {
 			info.Version = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["3000/tcp"]; ok && len(ports) > 0 {
-			if ports[0].HostPort != "" {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-			}
-		}
 	}
 	return info
 }
 
-func (g *GrafanaContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if g.Version != "" {
-		m["Version"] = g.Version
-	}
-	if g.AdminUser != "" {
-		m["Admin User"] = g.AdminUser
-	}
-	if g.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", g.Port)
-	}
-	return m
+func (g *GrafanaContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", g.Version),
+		domain.StringField("Admin User", g.AdminUser),
+		domain.IntField("Port", g.Port),
+	)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -58,22 +59,12 @@ func (s *ImmichStrategy) Extract(ctx context.Context, id string, raw map[string]
 	return info
 }
 
-func (i *ImmichContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if i.Version != "" {
-		m["Version"] = i.Version
-	}
-	if i.DBHost != "" {
-		m["DB Host"] = i.DBHost
-	}
-	if i.DBPort > 0 {
-		m["DB Port"] = fmt.Sprintf("%d", i.DBPort)
-	}
-	if i.RedisHost != "" {
-		m["Redis Host"] = i.RedisHost
-	}
-	if i.RedisPort > 0 {
-		m["Redis Port"] = fmt.Sprintf("%d", i.RedisPort)
-	}
-	return m
+func (i *ImmichContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", i.Version),
+		domain.StringField("DB Host", i.DBHost),
+		domain.IntField("DB Port", i.DBPort),
+		domain.StringField("Redis Host", i.RedisHost),
+		domain.IntField("Redis Port", i.RedisPort),
+	)
 }
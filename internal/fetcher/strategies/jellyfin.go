@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -21,38 +21,11 @@ func (s *JellyfinStrategy) Match(image string) bool {
 }
 
 func (s *JellyfinStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &JellyfinContainerInfo{BaseContainerInfo: base}
-	dockerClient, ok := client.(interface {
-		ContainerInspect(ctx context.Context, id string, v interface{}) error
-	})
-	if !ok {
-		return info
-	}
-
-	var inspect struct {
-		Config struct {
-			Env []string `json:"Env"`
-		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
-	}
-	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
-		if ports, ok := inspect.NetworkSettings.Ports["8096/tcp"]; ok && len(ports) > 0 {
-			if ports[0].HostPort != "" {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-			}
-		}
-	}
-	return info
+	return &JellyfinContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 8096)}
 }
 
-func (j *JellyfinContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if j.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", j.Port)
-	}
-	return m
+func (j *JellyfinContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.IntField("Port", j.Port),
+	)
 }
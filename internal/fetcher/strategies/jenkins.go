@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -22,7 +22,7 @@ func (s *JenkinsStrategy) Match(image string) bool {
 }
 
 func (s *JenkinsStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &JenkinsContainerInfo{BaseContainerInfo: base}
+	info := &JenkinsContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 8080)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(ctx context.Context, id string, v interface{}) error
 	})
@@ -34,11 +34,6 @@ func (s *JenkinsStrategy) Extract(ctx context.Context, id string, raw map[string
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -48,25 +43,14 @@ func (s *JenkinsStrategy) Extract(ctx context.Context, id string, raw map[string
 		if v, ok := envMap["JENKINS_ADMIN_ID"]; ok {
 			info.AdminUser = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["8080/tcp"]; ok && len(ports) > 0 {
-			if ports[0].HostPort != "" {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-			}
-		}
 	}
 	return info
 }
 
-func (j *JenkinsContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if j.Version != "" {
-		m["Version"] = j.Version
-	}
-	if j.AdminUser != "" {
-		m["Admin User"] = j.AdminUser
-	}
-	if j.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", j.Port)
-	}
-	return m
+func (j *JenkinsContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", j.Version),
+		domain.StringField("Admin User", j.AdminUser),
+		domain.IntField("Port", j.Port),
+	)
 }
@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -21,7 +21,7 @@ func (s *KibanaStrategy) Match(image string) bool {
 }
 
 func (s *KibanaStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &KibanaContainerInfo{BaseContainerInfo: base}
+	info := &KibanaContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 5601)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(ctx context.Context, id string, v interface{}) error
 	})
@@ -33,33 +33,19 @@ func (s *KibanaStrategy) Extract(ctx context.Context, id string, raw map[string]
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
 		if v, ok := envMap["KIBANA_VERSION"]; ok {
 			info.Version = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["5601/tcp"]; ok && len(ports) > 0 {
-			if ports[0].HostPort != "" {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-			}
-		}
 	}
 	return info
 }
 
-func (k *KibanaContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if k.Version != "" {
-		m["Version"] = k.Version
-	}
-	if k.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", k.Port)
-	}
-	return m
+func (k *KibanaContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", k.Version),
+		domain.IntField("Port", k.Port),
+	)
 }
@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -23,7 +23,7 @@ func (s *MariaDBStrategy) Match(image string) bool {
 }
 
 func (s *MariaDBStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &MariaDBContainerInfo{BaseContainerInfo: base}
+	info := &MariaDBContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 3306)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(ctx context.Context, id string, v interface{}) error
 	})
@@ -35,11 +35,6 @@ func (s *MariaDBStrategy) Extract(ctx context.Context, id string, raw map[string
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -52,28 +47,15 @@ func (s *MariaDBStrategy) Extract(ctx context.Context, id string, raw map[string
 		if v, ok := envMap["MARIADB_DATABASE"]; ok {
 			info.Database = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["3306/tcp"]; ok && len(ports) > 0 {
-			if ports[0].HostPort != "" {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-			}
-		}
 	}
 	return info
 }
 
-func (m *MariaDBContainerInfo) DetailFields() map[string]string {
-	mOut := map[string]string{}
-	if m.Version != "" {
-		mOut["Version"] = m.Version
-	}
-	if m.User != "" {
-		mOut["User"] = m.User
-	}
-	if m.Database != "" {
-		mOut["Database"] = m.Database
-	}
-	if m.Port > 0 {
-		mOut["Port"] = fmt.Sprintf("%d", m.Port)
-	}
-	return mOut
+func (m *MariaDBContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", m.Version),
+		domain.StringField("User", m.User),
+		domain.StringField("Database", m.Database),
+		domain.IntField("Port", m.Port),
+	)
 }
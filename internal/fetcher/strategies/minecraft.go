@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -18,24 +19,18 @@ type MinecraftContainerInfo struct {
 	OnlinePlayers int
 }
 
-func (mc *MinecraftContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if mc.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", mc.Port)
-	}
-	if mc.Version != "" {
-		m["Version"] = mc.Version
-	}
-	if mc.ServerType != "" {
-		m["Type"] = mc.ServerType
-	}
-	if mc.Difficulty != "" {
-		m["Difficulty"] = mc.Difficulty
-	}
+func (mc *MinecraftContainerInfo) DetailFields() []domain.Field {
+	players := domain.Field{}
 	if mc.MaxPlayers > 0 {
-		m["Players"] = fmt.Sprintf("%d/%d", mc.OnlinePlayers, mc.MaxPlayers)
+		players = domain.StringField("Players", fmt.Sprintf("%d/%d", mc.OnlinePlayers, mc.MaxPlayers))
 	}
-	return m
+	return domain.Fields(
+		domain.IntField("Port", mc.Port),
+		domain.StringField("Version", mc.Version),
+		domain.StringField("Type", mc.ServerType),
+		domain.StringField("Difficulty", mc.Difficulty),
+		players,
+	)
 }
 
 type MinecraftStrategy struct{}
@@ -46,7 +41,7 @@ func (s *MinecraftStrategy) Match(image string) bool {
 }
 
 func (s *MinecraftStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &MinecraftContainerInfo{BaseContainerInfo: base}
+	info := &MinecraftContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 25565)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(context.Context, string, interface{}) error
 	})
@@ -57,11 +52,6 @@ func (s *MinecraftStrategy) Extract(ctx context.Context, id string, raw map[stri
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -77,9 +67,6 @@ func (s *MinecraftStrategy) Extract(ctx context.Context, id string, raw map[stri
 		if v, ok := envMap["MAX_PLAYERS"]; ok {
 			fmt.Sscanf(v, "%d", &info.MaxPlayers)
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["25565/tcp"]; ok && len(ports) > 0 {
-			fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-		}
 	}
 	return info
 }
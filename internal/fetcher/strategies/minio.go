@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -23,7 +23,7 @@ func (s *MinioStrategy) Match(image string) bool {
 }
 
 func (s *MinioStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &MinioContainerInfo{BaseContainerInfo: base}
+	info := &MinioContainerInfo{BaseContainerInfo: base, ConsolePort: publicPort(base.Ports, 9001)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(ctx context.Context, id string, v interface{}) error
 	})
@@ -35,11 +35,6 @@ func (s *MinioStrategy) Extract(ctx context.Context, id string, raw map[string]i
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -49,25 +44,14 @@ func (s *MinioStrategy) Extract(ctx context.Context, id string, raw map[string]i
 		if v, ok := envMap["MINIO_ROOT_PASSWORD"]; ok {
 			info.SecretKey = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["9001/tcp"]; ok && len(ports) > 0 {
-			if ports[0].HostPort != "" {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.ConsolePort)
-			}
-		}
 	}
 	return info
 }
 
-func (m *MinioContainerInfo) DetailFields() map[string]string {
-	mOut := map[string]string{}
-	if m.AccessKey != "" {
-		mOut["Access Key"] = m.AccessKey
-	}
-	if m.SecretKey != "" {
-		mOut["Secret Key"] = m.SecretKey
-	}
-	if m.ConsolePort > 0 {
-		mOut["Console Port"] = fmt.Sprintf("%d", m.ConsolePort)
-	}
-	return mOut
+func (m *MinioContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Access Key", m.AccessKey),
+		domain.SecretField("Secret Key", m.SecretKey),
+		domain.IntField("Console Port", m.ConsolePort),
+	)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -15,21 +16,13 @@ type NextcloudContainerInfo struct {
 	DBName    string
 }
 
-func (n *NextcloudContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if n.Version != "" {
-		m["Version"] = n.Version
-	}
-	if n.AdminUser != "" {
-		m["Admin User"] = n.AdminUser
-	}
-	if n.DBHost != "" {
-		m["DB Host"] = n.DBHost
-	}
-	if n.DBName != "" {
-		m["DB Name"] = n.DBName
-	}
-	return m
+func (n *NextcloudContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", n.Version),
+		domain.StringField("Admin User", n.AdminUser),
+		domain.StringField("DB Host", n.DBHost),
+		domain.StringField("DB Name", n.DBName),
+	)
 }
 
 type NextcloudStrategy struct{}
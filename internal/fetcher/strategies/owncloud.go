@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -57,16 +58,10 @@ func (s *OwnCloudStrategy) Extract(ctx context.Context, id string, raw map[strin
 	return info
 }
 
-func (o *OwnCloudContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if o.Version != "" {
-		m["Version"] = o.Version
-	}
-	if o.DBHost != "" {
-		m["DB Host"] = o.DBHost
-	}
-	if o.DBName != "" {
-		m["DB Name"] = o.DBName
-	}
-	return m
+func (o *OwnCloudContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", o.Version),
+		domain.StringField("DB Host", o.DBHost),
+		domain.StringField("DB Name", o.DBName),
+	)
 }
@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -15,18 +15,12 @@ type PortainerContainerInfo struct {
 	Edition   string
 }
 
-func (pt *PortainerContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if pt.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", pt.Port)
-	}
-	if pt.Edition != "" {
-		m["Edition"] = pt.Edition
-	}
-	if pt.AdminUser != "" {
-		m["Admin"] = pt.AdminUser
-	}
-	return m
+func (pt *PortainerContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.IntField("Port", pt.Port),
+		domain.StringField("Edition", pt.Edition),
+		domain.StringField("Admin", pt.AdminUser),
+	)
 }
 
 type PortainerStrategy struct{}
@@ -36,7 +30,10 @@ func (s *PortainerStrategy) Match(image string) bool {
 }
 
 func (s *PortainerStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &PortainerContainerInfo{BaseContainerInfo: base, Edition: "Community"}
+	info := &PortainerContainerInfo{BaseContainerInfo: base, Edition: "Community", Port: publicPort(base.Ports, 9000)}
+	if info.Port == 0 {
+		info.Port = publicPort(base.Ports, 9443)
+	}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(context.Context, string, interface{}) error
 	})
@@ -47,11 +44,6 @@ func (s *PortainerStrategy) Extract(ctx context.Context, id string, raw map[stri
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -61,12 +53,6 @@ func (s *PortainerStrategy) Extract(ctx context.Context, id string, raw map[stri
 		if strings.Contains(strings.ToLower(base.Image), "portainer-ee") {
 			info.Edition = "Business"
 		}
-		for portKey, ports := range inspect.NetworkSettings.Ports {
-			if (strings.HasPrefix(portKey, "9000") || strings.HasPrefix(portKey, "9443")) && len(ports) > 0 {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-				break
-			}
-		}
 	}
 	return info
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -18,27 +19,15 @@ type PostgreSqlContainerInfo struct {
 	PGData         string
 }
 
-func (pg *PostgreSqlContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if pg.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", pg.Port)
-	}
-	if pg.Database != "" {
-		m["Database"] = pg.Database
-	}
-	if pg.User != "" {
-		m["User"] = pg.User
-	}
-	if pg.SSLMode != "" {
-		m["SSL Mode"] = pg.SSLMode
-	}
-	if pg.MaxConnections > 0 {
-		m["Max Conn"] = fmt.Sprintf("%d", pg.MaxConnections)
-	}
-	if pg.PGData != "" {
-		m["Volume"] = pg.PGData
-	}
-	return m
+func (pg *PostgreSqlContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.IntField("Port", pg.Port),
+		domain.StringField("Database", pg.Database),
+		domain.StringField("User", pg.User),
+		domain.StringField("SSL Mode", pg.SSLMode),
+		domain.IntField("Max Conn", pg.MaxConnections),
+		domain.StringField("Volume", pg.PGData).AsMinor(),
+	)
 }
 
 type PostgreSqlStrategy struct{}
@@ -48,7 +37,7 @@ func (s *PostgreSqlStrategy) Match(image string) bool {
 }
 
 func (s *PostgreSqlStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &PostgreSqlContainerInfo{BaseContainerInfo: base}
+	info := &PostgreSqlContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 5432)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(context.Context, string, interface{}) error
 	})
@@ -59,11 +48,6 @@ func (s *PostgreSqlStrategy) Extract(ctx context.Context, id string, raw map[str
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -82,9 +66,6 @@ func (s *PostgreSqlStrategy) Extract(ctx context.Context, id string, raw map[str
 		if v, ok := envMap["POSTGRES_MAX_CONNECTIONS"]; ok {
 			fmt.Sscanf(v, "%d", &info.MaxConnections)
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["5432/tcp"]; ok && len(ports) > 0 {
-			fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-		}
 	}
 	return info
 }
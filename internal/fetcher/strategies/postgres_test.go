@@ -2,8 +2,10 @@ package strategies
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -14,11 +16,6 @@ func (m *mockInspectClient) ContainerInspect(ctx context.Context, id string, v i
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	})
 	out.Config.Env = []string{
 		"POSTGRES_DB=mydb",
@@ -27,17 +24,16 @@ func (m *mockInspectClient) ContainerInspect(ctx context.Context, id string, v i
 		"PGDATA=/var/lib/postgresql/data",
 		"POSTGRES_MAX_CONNECTIONS=200",
 	}
-	out.NetworkSettings.Ports = map[string][]struct {
-		HostPort string `json:"HostPort"`
-	}{
-		"5432/tcp": {{HostPort: "5432"}},
-	}
 	return nil
 }
 
 func TestPostgreSqlStrategy_Extract(t *testing.T) {
 	s := &PostgreSqlStrategy{}
-	base := model.BaseContainerInfo{ID: "x", Image: "postgres", Names: []string{"/pg"}}
+	base := model.BaseContainerInfo{ID: "x", Image: "postgres", Names: []string{"/pg"}, Ports: []domain.Port{
+		{PrivatePort: 5432, Type: "udp", PublicPort: 6000},
+		{PrivatePort: 5432, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 5432, PublicPort: 15432, Type: "tcp"},
+	}}
 	raw := map[string]interface{}{}
 	res := s.Extract(context.Background(), "x", raw, base, &mockInspectClient{})
 	info, ok := res.(*PostgreSqlContainerInfo)
@@ -59,11 +55,21 @@ func TestPostgreSqlStrategy_Extract(t *testing.T) {
 	if info.MaxConnections != 200 {
 		t.Errorf("MaxConnections parse failed: %d", info.MaxConnections)
 	}
-	if info.Port != 5432 {
+	if info.Port != 15432 {
 		t.Errorf("Port parse failed: %d", info.Port)
 	}
 	fields := info.DetailFields()
-	if fields["Database"] != "mydb" || fields["User"] != "admin" || fields["Port"] != "5432" {
-		t.Errorf("unexpected detail fields: %#v", fields)
+	var names []string
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "Port,Database,User,SSL Mode,Max Conn,Volume" {
+		t.Errorf("unexpected detail field order: %s", got)
+	}
+	if f, _ := domain.LookupField(fields, "Max Conn"); f.Kind != domain.FieldInt || f.Num != 200 {
+		t.Errorf("Max Conn should be the int 200, got %#v", f)
+	}
+	if f, _ := domain.LookupField(fields, "Volume"); !f.Minor {
+		t.Errorf("Volume should be minor")
 	}
 }
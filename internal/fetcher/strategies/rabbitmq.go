@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -22,7 +22,7 @@ func (s *RabbitMQStrategy) Match(image string) bool {
 }
 
 func (s *RabbitMQStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &RabbitMQContainerInfo{BaseContainerInfo: base}
+	info := &RabbitMQContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 5672)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(ctx context.Context, id string, v interface{}) error
 	})
@@ -34,11 +34,6 @@ func (s *RabbitMQStrategy) Extract(ctx context.Context, id string, raw map[strin
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -48,25 +43,14 @@ func (s *RabbitMQStrategy) Extract(ctx context.Context, id string, raw map[strin
 		if v, ok := envMap["RABBITMQ_VERSION"]; ok {
 			info.Version = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["5672/tcp"]; ok && len(ports) > 0 {
-			if ports[0].HostPort != "" {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-			}
-		}
 	}
 	return info
 }
 
-func (r *RabbitMQContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if r.Version != "" {
-		m["Version"] = r.Version
-	}
-	if r.User != "" {
-		m["User"] = r.User
-	}
-	if r.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", r.Port)
-	}
-	return m
+func (r *RabbitMQContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", r.Version),
+		domain.StringField("User", r.User),
+		domain.IntField("Port", r.Port),
+	)
 }
@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -21,38 +21,11 @@ func (s *RadarrStrategy) Match(image string) bool {
 }
 
 func (s *RadarrStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &RadarrContainerInfo{BaseContainerInfo: base}
-	dockerClient, ok := client.(interface {
-		ContainerInspect(ctx context.Context, id string, v interface{}) error
-	})
-	if !ok {
-		return info
-	}
-
-	var inspect struct {
-		Config struct {
-			Env []string `json:"Env"`
-		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
-	}
-	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
-		if ports, ok := inspect.NetworkSettings.Ports["7878/tcp"]; ok && len(ports) > 0 {
-			if ports[0].HostPort != "" {
-				fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-			}
-		}
-	}
-	return info
+	return &RadarrContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 7878)}
 }
 
-func (r *RadarrContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if r.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", r.Port)
-	}
-	return m
+func (r *RadarrContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.IntField("Port", r.Port),
+	)
 }
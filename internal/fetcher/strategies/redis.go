@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -14,15 +14,11 @@ type RedisContainerInfo struct {
 	Password string
 }
 
-func (r *RedisContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if r.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", r.Port)
-	}
-	if r.Password != "" {
-		m["Password"] = r.Password
-	}
-	return m
+func (r *RedisContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.IntField("Port", r.Port),
+		domain.SecretField("Password", r.Password),
+	)
 }
 
 type RedisStrategy struct{}
@@ -32,7 +28,7 @@ func (s *RedisStrategy) Match(image string) bool {
 }
 
 func (s *RedisStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &RedisContainerInfo{BaseContainerInfo: base}
+	info := &RedisContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 6379)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(context.Context, string, interface{}) error
 	})
@@ -43,20 +39,12 @@ func (s *RedisStrategy) Extract(ctx context.Context, id string, raw map[string]i
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
 		if v, ok := envMap["REDIS_PASSWORD"]; ok {
 			info.Password = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["6379/tcp"]; ok && len(ports) > 0 {
-			fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-		}
 	}
 	return info
 }
@@ -2,7 +2,11 @@ package strategies
 
 import (
 	"context"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -10,3 +14,43 @@ type ContainerStrategy interface {
 	Match(image string) bool
 	Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{}
 }
+
+// StrategyEntry pairs a strategy with the type it detects; the entries come
+// from the plugin registry. WebPort is the container port the type serves
+// its web interface on, or zero.
+type StrategyEntry struct {
+	Type     domain.ContainerType
+	Strategy ContainerStrategy
+	WebPort  uint16
+}
+
+// publicPort is the host port the container's tcp port private is
+// published on, or zero when it isn't published.
+func publicPort(ports []domain.Port, private uint16) int {
+	for _, p := range ports {
+		if p.PrivatePort == private && p.Type == "tcp" && p.PublicPort != 0 {
+			return int(p.PublicPort)
+		}
+	}
+	return 0
+}
+
+// publicPorts lists the host ports the container publishes, in order.
+func publicPorts(ports []domain.Port) []int {
+	var out []int
+	for _, p := range ports {
+		if p.PublicPort != 0 && !slices.Contains(out, int(p.PublicPort)) {
+			out = append(out, int(p.PublicPort))
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+func joinPorts(ports []int) string {
+	ps := make([]string, len(ports))
+	for i, p := range ports {
+		ps[i] = strconv.Itoa(p)
+	}
+	return strings.Join(ps, ", ")
+}
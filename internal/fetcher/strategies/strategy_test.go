@@ -0,0 +1,26 @@
+package strategies
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+func TestPublicPorts(t *testing.T) {
+	ports := []domain.Port{
+		{PrivatePort: 80, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 8443, Type: "tcp"},
+		{IP: "127.0.0.1", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+		{IP: "0.0.0.0", PrivatePort: 443, PublicPort: 8443, Type: "udp"},
+	}
+	if got := publicPort(ports, 80); got != 8080 {
+		t.Errorf("publicPort(80) = %d, want 8080", got)
+	}
+	if got := publicPort(ports, 22); got != 0 {
+		t.Errorf("publicPort(22) = %d, want 0", got)
+	}
+	if got := publicPorts(ports); !slices.Equal(got, []int{8080, 8443}) {
+		t.Errorf("publicPorts = %v", got)
+	}
+}
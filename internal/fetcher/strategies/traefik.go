@@ -2,9 +2,10 @@ package strategies
 
 import (
 	"context"
-	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -16,25 +17,13 @@ type TraefikContainerInfo struct {
 	Ports       []int
 }
 
-func (t *TraefikContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if t.Version != "" {
-		m["Version"] = t.Version
-	}
-	if t.Entrypoints != "" {
-		m["Entrypoints"] = t.Entrypoints
-	}
-	if t.Dashboard {
-		m["Dashboard"] = "Enabled"
-	}
-	if len(t.Ports) > 0 {
-		var ps []string
-		for _, p := range t.Ports {
-			ps = append(ps, fmt.Sprintf("%d", p))
-		}
-		m["Ports"] = strings.Join(ps, ", ")
-	}
-	return m
+func (t *TraefikContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", t.Version),
+		domain.StringField("Entrypoints", t.Entrypoints),
+		domain.BoolField("Dashboard", t.Dashboard),
+		domain.StringField("Ports", joinPorts(t.Ports)),
+	)
 }
 
 type TraefikStrategy struct{}
@@ -43,8 +32,12 @@ func (s *TraefikStrategy) Match(image string) bool {
 	return strings.Contains(strings.ToLower(image), "traefik")
 }
 
+// Extract reads Traefik's static configuration from its command-line flags,
+// e.g. --entrypoints.web.address=:80 and --api.dashboard=true, or the
+// equivalent TRAEFIK_ENTRYPOINTS_WEB_ADDRESS style variables. The version
+// comes from the image's OCI label.
 func (s *TraefikStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &TraefikContainerInfo{BaseContainerInfo: base}
+	info := &TraefikContainerInfo{BaseContainerInfo: base, Version: base.Labels["org.opencontainers.image.version"], Ports: publicPorts(base.Ports)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(context.Context, string, interface{}) error
 	})
@@ -52,33 +45,48 @@ func (s *TraefikStrategy) Extract(ctx context.Context, id string, raw map[string
 		return info
 	}
 	var inspect struct {
+		Args   []string `json:"Args"`
 		Config struct {
 			Env []string `json:"Env"`
+			Cmd []string `json:"Cmd"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
-	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
-		envMap := model.ParseEnv(inspect.Config.Env)
-		if v, ok := envMap["TRAEFIK_VERSION"]; ok {
-			info.Version = v
-		}
-		if v, ok := envMap["TRAEFIK_ENTRYPOINTS"]; ok {
-			info.Entrypoints = v
+	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err != nil {
+		return info
+	}
+	static := map[string]string{}
+	for k, v := range model.ParseEnv(inspect.Config.Env) {
+		if key, ok := strings.CutPrefix(k, "TRAEFIK_"); ok {
+			static[strings.ToLower(strings.ReplaceAll(key, "_", "."))] = v
 		}
-		if v, ok := envMap["TRAEFIK_DASHBOARD"]; ok {
-			info.Dashboard = v == "true"
+	}
+	for _, arg := range append(inspect.Config.Cmd, inspect.Args...) {
+		if key, ok := strings.CutPrefix(arg, "--"); ok {
+			key, v, found := strings.Cut(key, "=")
+			if !found {
+				v = "true"
+			}
+			static[strings.ToLower(key)] = v
 		}
-		for _, ports := range inspect.NetworkSettings.Ports {
-			if len(ports) > 0 && ports[0].HostPort != "" {
-				var port int
-				fmt.Sscanf(ports[0].HostPort, "%d", &port)
-				info.Ports = append(info.Ports, port)
+	}
+	var entrypoints []string
+	for k := range static {
+		if name, ok := strings.CutPrefix(k, "entrypoints."); ok {
+			if name, ok = strings.CutSuffix(name, ".address"); ok && !strings.Contains(name, ".") {
+				entrypoints = append(entrypoints, name)
 			}
 		}
 	}
+	sort.Strings(entrypoints)
+	info.Entrypoints = strings.Join(entrypoints, ", ")
+	if v := static["entrypoints"]; info.Entrypoints == "" && v != "" {
+		info.Entrypoints = v
+	}
+	if v := static["version"]; info.Version == "" {
+		info.Version = v
+	}
+	// the dashboard is on by default once the API is
+	api := static["api"] == "true" || static["api.insecure"] == "true" || static["api.dashboard"] == "true"
+	info.Dashboard = static["dashboard"] == "true" || (api && static["api.dashboard"] != "false")
 	return info
 }
@@ -0,0 +1,47 @@
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/model"
+)
+
+// inspectJSON answers ContainerInspect with a canned daemon response.
+type inspectJSON string
+
+func (j inspectJSON) ContainerInspect(ctx context.Context, id string, v interface{}) error {
+	return json.Unmarshal([]byte(j), v)
+}
+
+func TestTraefikStrategy_ExtractFlags(t *testing.T) {
+	client := inspectJSON(`{
+		"Args": ["--api.insecure=true", "--providers.docker", "--entrypoints.websecure.address=:443", "--entrypoints.web.address=:80"],
+		"Config": {"Env": ["PATH=/usr/bin"]}
+	}`)
+	base := model.BaseContainerInfo{Labels: map[string]string{"org.opencontainers.image.version": "v3.1.2"}, Ports: []domain.Port{
+		{PrivatePort: 443, PublicPort: 443, Type: "tcp"}, {PrivatePort: 8080, PublicPort: 8080, Type: "tcp"}, {PrivatePort: 80, PublicPort: 80, Type: "tcp"},
+	}}
+	info := (&TraefikStrategy{}).Extract(context.Background(), "t", nil, base, client).(*TraefikContainerInfo)
+	if info.Version != "v3.1.2" || info.Entrypoints != "web, websecure" || !info.Dashboard {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	if len(info.Ports) != 3 || info.Ports[0] != 80 || info.Ports[2] != 8080 {
+		t.Errorf("ports should be sorted: %v", info.Ports)
+	}
+}
+
+func TestTraefikStrategy_ExtractEnv(t *testing.T) {
+	client := inspectJSON(`{"Config": {"Env": ["TRAEFIK_ENTRYPOINTS_WEB_ADDRESS=:80", "TRAEFIK_API=true", "TRAEFIK_API_DASHBOARD=false"]}}`)
+	info := (&TraefikStrategy{}).Extract(context.Background(), "t", nil, model.BaseContainerInfo{}, client).(*TraefikContainerInfo)
+	if info.Entrypoints != "web" || info.Dashboard {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	legacy := inspectJSON(`{"Config": {"Env": ["TRAEFIK_VERSION=2.11", "TRAEFIK_ENTRYPOINTS=web,websecure", "TRAEFIK_DASHBOARD=true"]}}`)
+	info = (&TraefikStrategy{}).Extract(context.Background(), "t", nil, model.BaseContainerInfo{}, legacy).(*TraefikContainerInfo)
+	if info.Version != "2.11" || info.Entrypoints != "web,websecure" || !info.Dashboard {
+		t.Errorf("legacy variables not read: %+v", info)
+	}
+}
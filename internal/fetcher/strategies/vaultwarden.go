@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -15,18 +15,12 @@ type VaultwardenContainerInfo struct {
 	Port       int
 }
 
-func (v *VaultwardenContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if v.Version != "" {
-		m["Version"] = v.Version
-	}
-	if v.AdminToken != "" {
-		m["Admin Token"] = v.AdminToken
-	}
-	if v.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", v.Port)
-	}
-	return m
+func (v *VaultwardenContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", v.Version),
+		domain.SecretField("Admin Token", v.AdminToken),
+		domain.IntField("Port", v.Port),
+	)
 }
 
 type VaultwardenStrategy struct{}
@@ -36,7 +30,7 @@ func (s *VaultwardenStrategy) Match(image string) bool {
 }
 
 func (s *VaultwardenStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &VaultwardenContainerInfo{BaseContainerInfo: base}
+	info := &VaultwardenContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 80)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(context.Context, string, interface{}) error
 	})
@@ -47,11 +41,6 @@ func (s *VaultwardenStrategy) Extract(ctx context.Context, id string, raw map[st
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -61,9 +50,6 @@ func (s *VaultwardenStrategy) Extract(ctx context.Context, id string, raw map[st
 		if v, ok := envMap["VAULTWARDEN_VERSION"]; ok {
 			info.Version = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["80/tcp"]; ok && len(ports) > 0 {
-			fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-		}
 	}
 	return info
 }
@@ -2,9 +2,9 @@ package strategies
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/model"
 )
 
@@ -16,21 +16,13 @@ type WordPressContainerInfo struct {
 	Port    int
 }
 
-func (w *WordPressContainerInfo) DetailFields() map[string]string {
-	m := map[string]string{}
-	if w.Version != "" {
-		m["Version"] = w.Version
-	}
-	if w.DBHost != "" {
-		m["DB Host"] = w.DBHost
-	}
-	if w.DBName != "" {
-		m["DB Name"] = w.DBName
-	}
-	if w.Port > 0 {
-		m["Port"] = fmt.Sprintf("%d", w.Port)
-	}
-	return m
+func (w *WordPressContainerInfo) DetailFields() []domain.Field {
+	return domain.Fields(
+		domain.StringField("Version", w.Version),
+		domain.StringField("DB Host", w.DBHost),
+		domain.StringField("DB Name", w.DBName),
+		domain.IntField("Port", w.Port),
+	)
 }
 
 type WordPressStrategy struct{}
@@ -40,7 +32,7 @@ func (s *WordPressStrategy) Match(image string) bool {
 }
 
 func (s *WordPressStrategy) Extract(ctx context.Context, id string, raw map[string]interface{}, base model.BaseContainerInfo, client interface{}) interface{} {
-	info := &WordPressContainerInfo{BaseContainerInfo: base}
+	info := &WordPressContainerInfo{BaseContainerInfo: base, Port: publicPort(base.Ports, 80)}
 	dockerClient, ok := client.(interface {
 		ContainerInspect(context.Context, string, interface{}) error
 	})
@@ -51,11 +43,6 @@ func (s *WordPressStrategy) Extract(ctx context.Context, id string, raw map[stri
 		Config struct {
 			Env []string `json:"Env"`
 		} `json:"Config"`
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostPort string `json:"HostPort"`
-			} `json:"Ports"`
-		} `json:"NetworkSettings"`
 	}
 	if err := dockerClient.ContainerInspect(ctx, id, &inspect); err == nil {
 		envMap := model.ParseEnv(inspect.Config.Env)
@@ -68,9 +55,6 @@ func (s *WordPressStrategy) Extract(ctx context.Context, id string, raw map[stri
 		if v, ok := envMap["WORDPRESS_DB_NAME"]; ok {
 			info.DBName = v
 		}
-		if ports, ok := inspect.NetworkSettings.Ports["80/tcp"]; ok && len(ports) > 0 {
-			fmt.Sscanf(ports[0].HostPort, "%d", &info.Port)
-		}
 	}
 	return info
 }
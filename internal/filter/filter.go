@@ -0,0 +1,305 @@
+// Package filter parses the container query language shared by the TUI
+// filter prompt and the --filter flag.
+//
+// A query is a list of terms that must all match:
+//
+//	status:running      effective state (running, unhealthy, exited, ...)
+//	type:postgresql     container type
+//	name:api            name contains, case-insensitive; name:~^api- is a regex
+//	image:nginx         image contains; image:~... is a regex
+//	project:shop        compose project
+//	health:healthy      healthcheck status, none without one
+//	label:env=prod      label value; label:env~^pr for a regex, label:env for presence
+//	host:box            daemon host name
+//	cpu>20 mem>500      thresholds on cpu (%), mem (MB), mem_pct, restarts and pids
+//	                    with >, >=, <, <=, = and !=
+//	api                 bare words match the name or image
+//
+// A leading - or ! negates a term, and double quotes keep spaces in a value.
+package filter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+type Filter struct {
+	// Host is what host: terms compare against; callers set it to the daemon
+	// the containers came from.
+	Host string
+
+	query string
+	terms []term
+}
+
+type term struct {
+	negate bool
+	match  func(f *Filter, c domain.Container) bool
+}
+
+// Parse compiles a query. An empty query gives a filter that matches
+// everything.
+func Parse(query string) (*Filter, error) {
+	f := &Filter{query: strings.TrimSpace(query)}
+	tokens, err := tokenize(f.query)
+	if err != nil {
+		return nil, err
+	}
+	for _, tok := range tokens {
+		t, err := parseTerm(tok)
+		if err != nil {
+			return nil, err
+		}
+		f.terms = append(f.terms, t)
+	}
+	return f, nil
+}
+
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.query
+}
+
+// Empty reports whether the filter lets everything through.
+func (f *Filter) Empty() bool { return f == nil || len(f.terms) == 0 }
+
+func (f *Filter) Match(c domain.Container) bool {
+	if f == nil {
+		return true
+	}
+	for _, t := range f.terms {
+		if t.match(f, c) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the matching containers, keeping their order.
+func (f *Filter) Apply(containers []domain.Container) []domain.Container {
+	if f.Empty() {
+		return containers
+	}
+	out := make([]domain.Container, 0, len(containers))
+	for _, c := range containers {
+		if f.Match(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func tokenize(q string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	quoted, inToken := false, false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inToken = true
+		case (r == ' ' || r == '\t') && !quoted:
+			if inToken {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				inToken = false
+			}
+		default:
+			cur.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", q)
+	}
+	if inToken {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+var comparison = regexp.MustCompile(`^([a-z_]+)(>=|<=|!=|>|<|=)(.+)$`)
+
+var numeric = map[string]func(c domain.Container) float64{
+	"cpu":      func(c domain.Container) float64 { return c.CPUPercent },
+	"mem":      func(c domain.Container) float64 { return float64(c.MemoryMB) },
+	"mem_pct":  func(c domain.Container) float64 { return c.MemoryPercent() },
+	"restarts": func(c domain.Container) float64 { return float64(c.Lifecycle.RestartCount) },
+	"pids":     func(c domain.Container) float64 { return float64(c.PIDs) },
+}
+
+func parseTerm(tok string) (term, error) {
+	t := term{}
+	if len(tok) > 1 && (tok[0] == '-' || tok[0] == '!') {
+		t.negate = true
+		tok = tok[1:]
+	}
+	if m := comparison.FindStringSubmatch(tok); m != nil {
+		if get, ok := numeric[m[1]]; ok {
+			want, err := strconv.ParseFloat(m[3], 64)
+			if err != nil {
+				return t, fmt.Errorf("%s: %q is not a number", m[1], m[3])
+			}
+			op := m[2]
+			t.match = func(_ *Filter, c domain.Container) bool { return compare(get(c), op, want) }
+			return t, nil
+		}
+	}
+	key, value, ok := strings.Cut(tok, ":")
+	if !ok {
+		word := strings.ToLower(tok)
+		t.match = func(_ *Filter, c domain.Container) bool {
+			return strings.Contains(strings.ToLower(c.Name()), word) || strings.Contains(strings.ToLower(c.Image), word)
+		}
+		return t, nil
+	}
+	var err error
+	switch strings.ToLower(key) {
+	case "status", "state":
+		t.match = exact(value, func(_ *Filter, c domain.Container) []string {
+			return []string{domain.EffectiveState(c.Status, c.Health), c.Status}
+		})
+	case "type":
+		t.match = exact(value, func(_ *Filter, c domain.Container) []string { return []string{string(c.Type)} })
+	case "project":
+		t.match = exact(value, func(_ *Filter, c domain.Container) []string { return []string{c.ComposeProject()} })
+	case "health":
+		t.match = exact(value, func(_ *Filter, c domain.Container) []string {
+			if c.Health == nil {
+				return []string{"none"}
+			}
+			return []string{string(c.Health.Status)}
+		})
+	case "host":
+		t.match = exact(value, func(f *Filter, _ domain.Container) []string { return []string{f.Host} })
+	case "name":
+		t.match, err = contains(value, func(c domain.Container) string { return c.Name() })
+	case "image":
+		t.match, err = contains(value, func(c domain.Container) string { return c.Image })
+	case "id":
+		t.match = func(_ *Filter, c domain.Container) bool { return value != "" && strings.HasPrefix(c.ID, value) }
+	case "label":
+		t.match, err = labelTerm(value)
+	default:
+		if _, ok := numeric[key]; ok {
+			return t, fmt.Errorf("%s needs a comparison such as %s>10", key, key)
+		}
+		return t, fmt.Errorf("unknown filter key %q", key)
+	}
+	return t, err
+}
+
+func exact(want string, values func(f *Filter, c domain.Container) []string) func(*Filter, domain.Container) bool {
+	return func(f *Filter, c domain.Container) bool {
+		for _, v := range values(f, c) {
+			if strings.EqualFold(v, want) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// contains matches a case-insensitive substring, or a regex after ~.
+func contains(value string, get func(c domain.Container) string) (func(*Filter, domain.Container) bool, error) {
+	if expr, ok := strings.CutPrefix(value, "~"); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("bad regex %q: %w", expr, err)
+		}
+		return func(_ *Filter, c domain.Container) bool { return re.MatchString(get(c)) }, nil
+	}
+	value = strings.ToLower(value)
+	return func(_ *Filter, c domain.Container) bool { return strings.Contains(strings.ToLower(get(c)), value) }, nil
+}
+
+func labelTerm(value string) (func(*Filter, domain.Container) bool, error) {
+	if i := strings.IndexAny(value, "=~"); i >= 0 {
+		key, want := value[:i], value[i+1:]
+		if value[i] == '=' {
+			return func(_ *Filter, c domain.Container) bool {
+				v, ok := c.Labels[key]
+				return ok && v == want
+			}, nil
+		}
+		re, err := regexp.Compile(want)
+		if err != nil {
+			return nil, fmt.Errorf("bad regex %q: %w", want, err)
+		}
+		return func(_ *Filter, c domain.Container) bool {
+			v, ok := c.Labels[key]
+			return ok && re.MatchString(v)
+		}, nil
+	}
+	return func(_ *Filter, c domain.Container) bool {
+		_, ok := c.Labels[value]
+		return ok
+	}, nil
+}
+
+func compare(got float64, op string, want float64) bool {
+	switch op {
+	case ">":
+		return got > want
+	case ">=":
+		return got >= want
+	case "<":
+		return got < want
+	case "<=":
+		return got <= want
+	case "=":
+		return got == want
+	default:
+		return got != want
+	}
+}
+
+type Consumer interface {
+	Consume(ctx context.Context, containers []domain.Container)
+}
+
+type Source interface {
+	FetchAll(ctx context.Context) ([]domain.Container, error)
+}
+
+type filteredConsumer struct {
+	f    *Filter
+	next Consumer
+}
+
+func (c filteredConsumer) Consume(ctx context.Context, containers []domain.Container) {
+	c.next.Consume(ctx, c.f.Apply(containers))
+}
+
+// Consumer passes only matching containers on to next.
+func (f *Filter) Consumer(next Consumer) Consumer {
+	if f.Empty() {
+		return next
+	}
+	return filteredConsumer{f, next}
+}
+
+type filteredSource struct {
+	f   *Filter
+	src Source
+}
+
+func (s filteredSource) FetchAll(ctx context.Context) ([]domain.Container, error) {
+	containers, err := s.src.FetchAll(ctx)
+	return s.f.Apply(containers), err
+}
+
+// Source filters what src returns.
+func (f *Filter) Source(src Source) Source {
+	if f.Empty() {
+		return src
+	}
+	return filteredSource{f, src}
+}
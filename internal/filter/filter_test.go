@@ -0,0 +1,112 @@
+package filter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+func containers() []domain.Container {
+	return []domain.Container{
+		{ID: "aaa111", Names: []string{"/api-gateway"}, Image: "acme/api:2", Status: "running", Type: domain.ContainerTypeGeneric,
+			CPUPercent: 35, MemoryMB: 800, MemoryLimitMB: 1000, Labels: map[string]string{"env": "prod", domain.LabelComposeProject: "shop"}},
+		{ID: "bbb222", Names: []string{"/orders-db"}, Image: "postgres:16", Status: "running", Type: domain.ContainerTypePostgreSQL,
+			CPUPercent: 5, MemoryMB: 300, Labels: map[string]string{"env": "staging"}, Health: &domain.Health{Status: domain.HealthUnhealthy}},
+		{ID: "ccc333", Names: []string{"/batch"}, Image: "acme/batch", Status: "exited", Type: domain.ContainerTypeGeneric,
+			Lifecycle: domain.Lifecycle{RestartCount: 4}},
+	}
+}
+
+func names(cs []domain.Container) []string {
+	out := make([]string, len(cs))
+	for i, c := range cs {
+		out[i] = c.Name()
+	}
+	return out
+}
+
+func TestFilterQueries(t *testing.T) {
+	for query, want := range map[string][]string{
+		"":                                  {"api-gateway", "orders-db", "batch"},
+		"status:running":                    {"api-gateway", "orders-db"},
+		"status:unhealthy":                  {"orders-db"},
+		"-status:running":                   {"batch"},
+		"type:postgresql":                   {"orders-db"},
+		"name:~^api-":                       {"api-gateway"},
+		"name:DB":                           {"orders-db"},
+		"image:acme":                        {"api-gateway", "batch"},
+		"label:env=prod":                    {"api-gateway"},
+		"label:env~^(prod|staging)$ cpu<10": {"orders-db"},
+		"label:env":                         {"api-gateway", "orders-db"},
+		"!label:env":                        {"batch"},
+		"cpu>20 mem>500":                    {"api-gateway"},
+		"mem_pct>=80":                       {"api-gateway"},
+		"restarts>3":                        {"batch"},
+		"project:shop":                      {"api-gateway"},
+		"health:none":                       {"api-gateway", "batch"},
+		"host:box":                          {"api-gateway", "orders-db", "batch"},
+		"host:other":                        {},
+		"id:bbb":                            {"orders-db"},
+		"batch":                             {"batch"},
+		`"orders-db" status:running`:        {"orders-db"},
+	} {
+		f, err := Parse(query)
+		if err != nil {
+			t.Errorf("%q: %v", query, err)
+			continue
+		}
+		f.Host = "box"
+		got := names(f.Apply(containers()))
+		if len(got) != len(want) {
+			t.Errorf("%q: got %v, want %v", query, got, want)
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("%q: got %v, want %v", query, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	for _, query := range []string{"colour:red", "name:~(", "cpu>lots", "cpu:10", `name:"open`, "label:env~["} {
+		if _, err := Parse(query); err == nil {
+			t.Errorf("%q: expected error", query)
+		}
+	}
+}
+
+func TestNilFilterMatchesAll(t *testing.T) {
+	var f *Filter
+	if !f.Empty() || !f.Match(containers()[0]) || len(f.Apply(containers())) != 3 || f.String() != "" {
+		t.Error("nil filter should be a no-op")
+	}
+}
+
+type recorder struct{ got []domain.Container }
+
+func (r *recorder) Consume(ctx context.Context, containers []domain.Container) { r.got = containers }
+
+type source []domain.Container
+
+func (s source) FetchAll(ctx context.Context) ([]domain.Container, error) { return s, nil }
+
+func TestConsumerAndSourceWrappers(t *testing.T) {
+	f, _ := Parse("status:exited")
+	rec := &recorder{}
+	f.Consumer(rec).Consume(context.Background(), containers())
+	if got := names(rec.got); len(got) != 1 || got[0] != "batch" {
+		t.Errorf("consumer got %v", got)
+	}
+	got, _ := f.Source(source(containers())).FetchAll(context.Background())
+	if len(got) != 1 {
+		t.Errorf("source got %v", names(got))
+	}
+	var none *Filter
+	if none.Consumer(rec) != Consumer(rec) {
+		t.Error("empty filter should not wrap")
+	}
+}
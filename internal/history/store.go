@@ -0,0 +1,348 @@
+// Package history keeps per-container CPU and memory samples on disk so past
+// usage can be charted.
+//
+// Samples go to one append-only JSON Lines file per UTC day, raw-YYYY-MM-DD.jsonl.
+// Once a day is older than the full-resolution window it is rewritten as
+// min-YYYY-MM-DD.jsonl with one sample per container and bucket (average CPU,
+// peak memory), and files older than the retention are deleted. A torn last
+// line from a crash is skipped when reading.
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	ilog "github.com/wosiu6/docky-go/internal/log"
+)
+
+type Sample struct {
+	Time       time.Time `json:"t"`
+	ID         string    `json:"id"`
+	Name       string    `json:"n"`
+	CPU        float64   `json:"cpu"`
+	MemMB      uint64    `json:"mem"`
+	MemLimitMB uint64    `json:"lim,omitempty"`
+}
+
+type Options struct {
+	// Interval is the minimum time between two samples of one container.
+	Interval time.Duration
+	// FullResolution is how long raw samples are kept before downsampling.
+	FullResolution time.Duration
+	// Bucket is the downsampled resolution.
+	Bucket time.Duration
+	// Retention is how long any sample is kept.
+	Retention time.Duration
+}
+
+func DefaultOptions() Options {
+	return Options{Interval: 10 * time.Second, FullResolution: 24 * time.Hour, Bucket: time.Minute, Retention: 7 * 24 * time.Hour}
+}
+
+// DefaultDir is $XDG_DATA_HOME/docky-go/history, falling back to
+// ~/.local/share.
+func DefaultDir() string {
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(os.TempDir(), "docky-go", "history")
+		}
+		base = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(base, "docky-go", "history")
+}
+
+const (
+	rawPrefix  = "raw-"
+	downPrefix = "min-"
+	dayLayout  = "2006-01-02"
+	maintEvery = time.Hour
+)
+
+type Store struct {
+	dir    string
+	opts   Options
+	logger ilog.Logger
+	now    func() time.Time
+
+	mu        sync.Mutex
+	f         *os.File
+	day       string
+	last      map[string]time.Time
+	lastMaint time.Time
+
+	// segments is held for reading by Query and for writing while maintain
+	// rewrites and deletes day files, so slow reads never hold up Append.
+	segments sync.RWMutex
+}
+
+func Open(dir string, opts Options, logger ilog.Logger) (*Store, error) {
+	if opts.Interval < 0 || opts.Bucket <= 0 || opts.FullResolution <= 0 || opts.Retention < opts.FullResolution {
+		return nil, fmt.Errorf("invalid history options %+v", opts)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	s := &Store{dir: dir, opts: opts, logger: logger, now: time.Now, last: make(map[string]time.Time)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.segments.Lock()
+	defer s.segments.Unlock()
+	if err := s.maintain(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Consume records one sample per container, at most once per Interval. It is
+// meant to be registered as an orchestrator consumer.
+func (s *Store) Consume(ctx context.Context, containers []domain.Container) {
+	now := s.now()
+	samples := make([]Sample, 0, len(containers))
+	seen := make(map[string]bool, len(containers))
+	s.mu.Lock()
+	for _, c := range containers {
+		seen[c.ID] = true
+		if now.Sub(s.last[c.ID]) < s.opts.Interval {
+			continue
+		}
+		s.last[c.ID] = now
+		samples = append(samples, Sample{Time: now.UTC(), ID: c.ID, Name: c.Name(), CPU: c.CPUPercent, MemMB: c.MemoryMB, MemLimitMB: c.MemoryLimitMB})
+	}
+	for id := range s.last {
+		if !seen[id] {
+			delete(s.last, id)
+		}
+	}
+	s.mu.Unlock()
+	if err := s.Append(samples...); err != nil && s.logger != nil {
+		s.logger.Error("history write failed", "error", err)
+	}
+}
+
+func (s *Store) Append(samples ...Sample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	// maintenance waits for the next append while a query is reading
+	if now.Sub(s.lastMaint) >= maintEvery && s.segments.TryLock() {
+		err := s.maintain()
+		s.segments.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	var buf []byte
+	for _, sm := range samples {
+		b, err := json.Marshal(sm)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, b...), '\n')
+	}
+	day := now.UTC().Format(dayLayout)
+	if s.f == nil || s.day != day {
+		if s.f != nil {
+			s.f.Close()
+		}
+		f, err := os.OpenFile(filepath.Join(s.dir, rawPrefix+day+".jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			s.f = nil
+			return err
+		}
+		s.f, s.day = f, day
+	}
+	_, err := s.f.Write(buf)
+	return err
+}
+
+// Query returns the samples of the named container between from and to,
+// oldest first. Names are used rather than IDs so history survives the
+// container being recreated.
+func (s *Store) Query(name string, from, to time.Time) ([]Sample, error) {
+	s.segments.RLock()
+	defer s.segments.RUnlock()
+	var out []Sample
+	for day := from.UTC().Truncate(24 * time.Hour); !day.After(to); day = day.Add(24 * time.Hour) {
+		for _, prefix := range []string{downPrefix, rawPrefix} {
+			samples, err := readSegment(filepath.Join(s.dir, prefix+day.Format(dayLayout)+".jsonl"))
+			if err != nil {
+				return nil, err
+			}
+			for _, sm := range samples {
+				if sm.Name == name && !sm.Time.Before(from) && !sm.Time.After(to) {
+					out = append(out, sm)
+				}
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time.Before(out[j].Time) })
+	return out, nil
+}
+
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// maintain downsamples raw days that left the full-resolution window and
+// deletes days past the retention. The caller holds mu and segments.
+func (s *Store) maintain() error {
+	now := s.now()
+	s.lastMaint = now
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		prefix, day, ok := parseSegment(e.Name())
+		if !ok {
+			continue
+		}
+		end := day.Add(24 * time.Hour)
+		path := filepath.Join(s.dir, e.Name())
+		switch {
+		case now.Sub(end) > s.opts.Retention:
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		case prefix == rawPrefix && now.Sub(end) > s.opts.FullResolution:
+			if s.f != nil && s.day == day.Format(dayLayout) {
+				s.f.Close()
+				s.f = nil
+			}
+			if err := s.downsample(path, filepath.Join(s.dir, downPrefix+day.Format(dayLayout)+".jsonl")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Store) downsample(rawPath, downPath string) error {
+	samples, err := readSegment(rawPath)
+	if err != nil {
+		return err
+	}
+	existing, err := readSegment(downPath)
+	if err != nil {
+		return err
+	}
+	merged := Downsample(append(existing, samples...), s.opts.Bucket)
+	tmp := downPath + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, sm := range merged {
+		if err := enc.Encode(sm); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := errors.Join(w.Flush(), f.Close()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, downPath); err != nil {
+		return err
+	}
+	return os.Remove(rawPath)
+}
+
+// Downsample merges samples into one per container name and bucket, averaging
+// CPU and keeping the peak memory so short spikes stay visible.
+func Downsample(samples []Sample, bucket time.Duration) []Sample {
+	type key struct {
+		name string
+		t    time.Time
+	}
+	type acc struct {
+		Sample
+		cpuSum float64
+		n      int
+	}
+	groups := map[key]*acc{}
+	var order []key
+	for _, sm := range samples {
+		k := key{sm.Name, sm.Time.Truncate(bucket)}
+		a, ok := groups[k]
+		if !ok {
+			a = &acc{Sample: Sample{Time: k.t, ID: sm.ID, Name: sm.Name}}
+			groups[k] = a
+			order = append(order, k)
+		}
+		a.cpuSum += sm.CPU
+		a.n++
+		a.ID = sm.ID
+		a.MemMB = max(a.MemMB, sm.MemMB)
+		a.MemLimitMB = max(a.MemLimitMB, sm.MemLimitMB)
+	}
+	out := make([]Sample, 0, len(order))
+	for _, k := range order {
+		a := groups[k]
+		a.CPU = math.Round(a.cpuSum/float64(a.n)*100) / 100
+		out = append(out, a.Sample)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Time.Equal(out[j].Time) {
+			return out[i].Time.Before(out[j].Time)
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func parseSegment(name string) (prefix string, day time.Time, ok bool) {
+	for _, p := range []string{rawPrefix, downPrefix} {
+		if rest, found := strings.CutPrefix(name, p); found {
+			d, err := time.Parse(dayLayout, strings.TrimSuffix(rest, ".jsonl"))
+			return p, d, err == nil && strings.HasSuffix(rest, ".jsonl")
+		}
+	}
+	return "", time.Time{}, false
+}
+
+// readSegment reads a segment file; a missing file is empty and lines that
+// do not parse, such as a write torn by a crash, are skipped.
+func readSegment(path string) ([]Sample, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var out []Sample
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var sm Sample
+		if json.Unmarshal(sc.Bytes(), &sm) == nil {
+			out = append(out, sm)
+		}
+	}
+	return out, sc.Err()
+}
@@ -0,0 +1,136 @@
+package history
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+func openAt(t *testing.T, dir string, now *time.Time) *Store {
+	t.Helper()
+	s, err := Open(dir, DefaultOptions(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return *now }
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestConsumeThrottlesAndQueries(t *testing.T) {
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	s := openAt(t, t.TempDir(), &now)
+	c := domain.Container{ID: "a1", Names: []string{"/api"}, CPUPercent: 12.5, MemoryMB: 300, MemoryLimitMB: 1024}
+	for range 30 {
+		s.Consume(context.Background(), []domain.Container{c})
+		now = now.Add(time.Second)
+		c.MemoryMB++
+	}
+	got, err := s.Query("api", now.Add(-time.Hour), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 samples at a 10s interval, got %d", len(got))
+	}
+	if got[0].MemMB != 300 || got[1].MemMB != 310 || got[0].CPU != 12.5 || got[0].MemLimitMB != 1024 {
+		t.Errorf("unexpected samples %+v", got)
+	}
+	if other, _ := s.Query("db", now.Add(-time.Hour), now); len(other) != 0 {
+		t.Errorf("query leaked other containers: %+v", other)
+	}
+}
+
+func TestConsumeForgetsRemovedContainers(t *testing.T) {
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	s := openAt(t, t.TempDir(), &now)
+	a := domain.Container{ID: "a1", Names: []string{"/api"}}
+	b := domain.Container{ID: "b1", Names: []string{"/db"}}
+	s.Consume(context.Background(), []domain.Container{a, b})
+	s.Consume(context.Background(), []domain.Container{a})
+	if _, ok := s.last["b1"]; ok || len(s.last) != 1 {
+		t.Errorf("expected only a1 to be tracked, got %v", s.last)
+	}
+}
+
+func TestAppendDoesNotWaitForQueries(t *testing.T) {
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	s := openAt(t, t.TempDir(), &now)
+	s.lastMaint = time.Time{}
+	// a query in progress
+	s.segments.RLock()
+	done := make(chan error, 1)
+	go func() { done <- s.Append(Sample{Time: now, Name: "api"}) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("append blocked on a query")
+	}
+	s.segments.RUnlock()
+	if !s.lastMaint.IsZero() {
+		t.Errorf("expected maintenance to be put off while reading")
+	}
+}
+
+func TestMaintainDownsamplesAndExpires(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	s := openAt(t, dir, &now)
+	old := now.Add(-10 * 24 * time.Hour)
+	s.now = func() time.Time { return old }
+	s.Append(Sample{Time: old, Name: "api", CPU: 1})
+	s.now = func() time.Time { return now }
+	for i, cpu := range []float64{10, 20, 30} {
+		s.Append(Sample{Time: now.Add(time.Duration(i) * 10 * time.Second), Name: "api", CPU: cpu, MemMB: uint64(100 * (i + 1))})
+	}
+
+	later := now.Add(3 * 24 * time.Hour)
+	s.now = func() time.Time { return later }
+	s.mu.Lock()
+	err := s.maintain()
+	s.mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if len(files) != 1 || filepath.Base(files[0]) != "min-2024-05-01.jsonl" {
+		t.Fatalf("expected only the downsampled day, got %v", files)
+	}
+	got, err := s.Query("api", now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].CPU != 20 || got[0].MemMB != 300 || !got[0].Time.Equal(now) {
+		t.Errorf("downsampled = %+v", got)
+	}
+}
+
+func TestQuerySkipsTornLines(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	s := openAt(t, dir, &now)
+	s.Append(Sample{Time: now, Name: "api", CPU: 5})
+	f, _ := os.OpenFile(filepath.Join(dir, "raw-2024-05-01.jsonl"), os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString(`{"t":"2024-05-01T03:00:10Z","n":"ap`)
+	f.Close()
+	got, err := s.Query("api", now.Add(-time.Minute), now.Add(time.Minute))
+	if err != nil || len(got) != 1 {
+		t.Errorf("got %+v, %v", got, err)
+	}
+}
+
+func TestOpenRejectsBadOptions(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Retention = time.Hour
+	if _, err := Open(t.TempDir(), opts, nil); err == nil {
+		t.Error("expected error when retention is shorter than full resolution")
+	}
+}
@@ -0,0 +1,97 @@
+// Package mask hides secrets, such as passwords read from a container's
+// environment, from the detail fields shown in the TUI and sent to the API
+// and exporters.
+package mask
+
+import (
+	"context"
+	"strings"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+const Hidden = "••••••"
+
+type Masker struct {
+	patterns []string
+}
+
+// New masks fields whose name contains any of patterns, case-insensitively,
+// and those strategies mark secret, even without patterns. A nil Masker
+// masks nothing.
+func New(patterns []string) *Masker {
+	m := &Masker{}
+	for _, p := range patterns {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			m.patterns = append(m.patterns, p)
+		}
+	}
+	return m
+}
+
+func (m *Masker) Secret(field string) bool {
+	if m == nil {
+		return false
+	}
+	field = strings.ToLower(field)
+	for _, p := range m.patterns {
+		if strings.Contains(field, p) {
+			return true
+		}
+	}
+	return false
+}
+
+type maskedDetails struct {
+	m    *Masker
+	next domain.DetailProvider
+}
+
+// DetailFields hides fields whose name matches a pattern as well as those the
+// strategy marks secret.
+func (d maskedDetails) DetailFields() []domain.Field {
+	fields := d.next.DetailFields()
+	out := make([]domain.Field, len(fields))
+	for i, f := range fields {
+		if !f.IsZero() && (f.Kind == domain.FieldSecret || d.m.Secret(f.Name)) {
+			f = domain.Field{Name: f.Name, Kind: domain.FieldSecret, Text: Hidden, Minor: f.Minor}
+		}
+		out[i] = f
+	}
+	return out
+}
+
+// Apply masks the details of every container in place.
+func (m *Masker) Apply(containers []domain.Container) []domain.Container {
+	if m == nil {
+		return containers
+	}
+	for i := range containers {
+		if d := containers[i].Details; d != nil {
+			containers[i].Details = maskedDetails{m, d}
+		}
+	}
+	return containers
+}
+
+type Source interface {
+	FetchAll(ctx context.Context) ([]domain.Container, error)
+}
+
+type maskedSource struct {
+	m   *Masker
+	src Source
+}
+
+func (s maskedSource) FetchAll(ctx context.Context) ([]domain.Container, error) {
+	containers, err := s.src.FetchAll(ctx)
+	return s.m.Apply(containers), err
+}
+
+// Source masks what src returns.
+func (m *Masker) Source(src Source) Source {
+	if m == nil {
+		return src
+	}
+	return maskedSource{m, src}
+}
@@ -0,0 +1,69 @@
+package mask
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+type details []domain.Field
+
+func (d details) DetailFields() []domain.Field { return d }
+
+type source []domain.Container
+
+func (s source) FetchAll(context.Context) ([]domain.Container, error) { return s, nil }
+
+func TestMasker_HidesSecretFields(t *testing.T) {
+	m := New([]string{"password", " Secret "})
+	src := source{{ID: "a", Details: details{
+		domain.StringField("Password", "hunter2"),
+		domain.StringField("Secret Key", "s3"),
+		domain.IntField("Port", 6379),
+		domain.StringField("Admin Password", ""),
+		domain.SecretField("Token", "t0k"),
+	}}, {ID: "b"}}
+	got, err := m.Source(src).FetchAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := got[0].Details.DetailFields()
+	for _, name := range []string{"Password", "Secret Key", "Token"} {
+		if f, _ := domain.LookupField(fields, name); f.String() != Hidden {
+			t.Fatalf("%s shown: %v", name, fields)
+		}
+	}
+	if f, _ := domain.LookupField(fields, "Port"); f.Kind != domain.FieldInt || f.Num != 6379 {
+		t.Fatalf("port changed: %v", fields)
+	}
+	if f, _ := domain.LookupField(fields, "Admin Password"); f.Text != "" {
+		t.Fatalf("empty secret filled in: %v", fields)
+	}
+	if fields[0].Name != "Password" || fields[4].Name != "Token" {
+		t.Fatalf("order changed: %v", fields)
+	}
+	if got[1].Details != nil {
+		t.Fatal("container without details gained some")
+	}
+}
+
+func TestMasker_NoPatternsStillHidesSecretFields(t *testing.T) {
+	m := New([]string{" ", ""})
+	got := m.Apply([]domain.Container{{ID: "a", Details: details{domain.SecretField("Admin Token", "x"), domain.StringField("Password", "y")}}})
+	fields := got[0].Details.DetailFields()
+	if fields[0].String() != Hidden || fields[1].String() != "y" {
+		t.Errorf("fields = %v", fields)
+	}
+}
+
+func TestMasker_NilPassesThrough(t *testing.T) {
+	var m *Masker
+	src := source{{ID: "a", Details: details{domain.StringField("Password", "x")}}}
+	if _, ok := m.Source(src).(source); !ok {
+		t.Fatal("nil masker should return the source unchanged")
+	}
+	if m.Secret("password") {
+		t.Fatal("nil masker masked a field")
+	}
+}
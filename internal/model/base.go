@@ -1,6 +1,10 @@
 package model
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
 
 type BaseContainerInfo struct {
 	ID         string
@@ -8,7 +12,23 @@ type BaseContainerInfo struct {
 	Image      string
 	CPUPercent float64
 	Mem        uint64
+	MemLimit   uint64
+	NetRx      uint64
+	NetTx      uint64
+	BlockRead  uint64
+	BlockWrite uint64
+	PIDs       uint64
+	Ports      []domain.Port
+	URL        string
+	Routes     []domain.Route
+	Routing    *domain.RoutingTable
+	Networks   []domain.Endpoint
 	Status     string
+	StatusText string
+	Labels     map[string]string
+	Health     *domain.Health
+	Lifecycle  domain.Lifecycle
+	Alerts     []domain.Alert
 }
 
 func ParseEnv(env []string) map[string]string {
@@ -17,40 +17,62 @@ type UiApp interface {
 	Run() error
 }
 
+type AlertEvaluator interface {
+	Evaluate(ctx context.Context, containers []domain.Container) []domain.Container
+}
+
+// Consumer receives every refreshed container list, after alerts have been
+// evaluated. Consumers run on the refresh loop and must not block for long.
+type Consumer interface {
+	Consume(ctx context.Context, containers []domain.Container)
+}
+
 type Orchestrator struct {
-	fetch    FetchService
-	ui       UiApp
-	logger   ilog.Logger
-	interval time.Duration
+	fetch     FetchService
+	ui        UiApp
+	logger    ilog.Logger
+	interval  time.Duration
+	alerts    AlertEvaluator
+	consumers []Consumer
 }
 
-func New(fetch FetchService, ui UiApp, logger ilog.Logger, interval time.Duration) *Orchestrator {
-	return &Orchestrator{fetch: fetch, ui: ui, logger: logger, interval: interval}
+type Option func(*Orchestrator)
+
+func WithAlerts(a AlertEvaluator) Option { return func(o *Orchestrator) { o.alerts = a } }
+func WithConsumer(c Consumer) Option {
+	return func(o *Orchestrator) { o.consumers = append(o.consumers, c) }
+}
+
+func New(fetch FetchService, ui UiApp, logger ilog.Logger, interval time.Duration, opts ...Option) *Orchestrator {
+	o := &Orchestrator{fetch: fetch, ui: ui, logger: logger, interval: interval}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
 }
 
 func (o *Orchestrator) Start(ctx context.Context) error {
 	errCh := make(chan error, 1)
-	go func() { errCh <- o.ui.Run() }()
-
-	go func() {
-		select {
-		case <-ctx.Done():
-			return
-		case <-time.After(300 * time.Millisecond):
-			if err := o.refreshOnce(ctx); err != nil {
-				o.logger.Error("initial fetch failed", "error", err)
-			}
-		}
-	}()
+	if o.ui != nil {
+		go func() { errCh <- o.ui.Run() }()
+	}
 
 	ticker := time.NewTicker(o.interval)
 	defer ticker.Stop()
+	// the first refresh runs on this loop too, so refreshes never overlap
+	// and consumers see them in order
+	first := time.After(300 * time.Millisecond)
 
 	for {
 		select {
 		case <-ctx.Done():
 			o.logger.Info("context canceled; stopping orchestrator")
 			return ctx.Err()
+		case <-first:
+			first = nil
+			if err := o.refreshOnce(ctx); err != nil {
+				o.logger.Error("initial fetch failed", "error", err)
+			}
 		case <-ticker.C:
 			if err := o.refreshOnce(ctx); err != nil {
 				o.logger.Error("periodic fetch failed", "error", err)
@@ -66,7 +88,15 @@ func (o *Orchestrator) refreshOnce(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if o.alerts != nil {
+		containers = o.alerts.Evaluate(ctx, containers)
+	}
 
-	o.ui.SetData(containers)
+	if o.ui != nil {
+		o.ui.SetData(containers)
+	}
+	for _, c := range o.consumers {
+		c.Consume(ctx, containers)
+	}
 	return nil
 }
@@ -0,0 +1,51 @@
+package plugin
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher/strategies"
+	"github.com/wosiu6/docky-go/internal/ui"
+)
+
+// cards shared by several built-in types
+var (
+	port        = []string{"Port"}
+	database    = ui.CardSpec{Border: lipgloss.DoubleBorder(), Headline: ui.FieldHeadline("DB: ", "Database"), Fields: []string{"User", "Port", "Version"}}
+	mediaServer = ui.CardSpec{Border: lipgloss.ThickBorder(), Headline: ui.FixedHeadline("Media server"), Fields: port}
+)
+
+var builtins = []Plugin{
+	{Type: domain.ContainerTypePostgreSQL, Strategy: &strategies.PostgreSqlStrategy{}, Icon: "\U0001F418", Color: "#336791", Render: ui.RenderPostgres},
+	{Type: domain.ContainerTypeMinecraft, Strategy: &strategies.MinecraftStrategy{}, Icon: "\u26CF\uFE0F", Color: "#55AA55", Render: ui.RenderMinecraft},
+	{Type: domain.ContainerTypePortainer, Strategy: &strategies.PortainerStrategy{}, Icon: "\U0001F6A2", Color: "#13BEF9", WebPort: 9000, Card: &ui.CardSpec{Border: lipgloss.ThickBorder(), Headline: ui.FieldHeadline("Portainer ", "Edition"), Fields: []string{"Port", "Admin"}}},
+	{Type: domain.ContainerTypeTraefik, Strategy: &strategies.TraefikStrategy{}, Icon: "\U0001F6A6", Color: "#24A1C1", WebPort: 8080, Render: ui.RenderTraefik},
+	{Type: domain.ContainerTypeImmich, Strategy: &strategies.ImmichStrategy{}, Icon: "\U0001F4F7", Color: "#4250AF", WebPort: 2283, Render: ui.RenderImmich},
+	{Type: domain.ContainerTypeOwnCloud, Strategy: &strategies.OwnCloudStrategy{}, Icon: "\U0001F325\uFE0F", Color: "#4E85C8", WebPort: 8080, Render: ui.RenderOwnCloud},
+	{Type: domain.ContainerTypeNginx, Strategy: &strategies.NginxStrategy{}, Icon: "\U0001F310", Color: "#009639", WebPort: 80, Render: ui.RenderNginx},
+	{Type: domain.ContainerTypeRedis, Strategy: &strategies.RedisStrategy{}, Icon: "\U0001F9E0", Color: "#D82C20", Render: ui.RenderRedis},
+	{Type: domain.ContainerTypeMySQL, Strategy: &strategies.MySQLStrategy{}, Icon: "\U0001F42C", Color: "#4479A1", Card: &database},
+	{Type: domain.ContainerTypeMongoDB, Strategy: &strategies.MongoDBStrategy{}, Icon: "\U0001F343", Color: "#47A248", Card: &ui.CardSpec{Border: lipgloss.DoubleBorder(), Headline: ui.FieldHeadline("DB: ", "Database"), Fields: port}},
+	{Type: domain.ContainerTypeGrafana, Strategy: &strategies.GrafanaStrategy{}, Icon: "\U0001F4CA", Color: "#F46800", WebPort: 3000, Render: ui.RenderGrafana},
+	{Type: domain.ContainerTypePrometheus, Strategy: &strategies.PrometheusStrategy{}, Icon: "\U0001F525", Color: "#E6522C", WebPort: 9090, Render: ui.RenderPrometheus},
+	{Type: domain.ContainerTypeNextcloud, Strategy: &strategies.NextcloudStrategy{}, Icon: "\u2601\uFE0F", Color: "#0082C9", WebPort: 80, Render: ui.RenderNextcloud},
+	{Type: domain.ContainerTypeMinio, Strategy: &strategies.MinioStrategy{}, Icon: "\U0001F5C4\uFE0F", Color: "#FFBD2E", WebPort: 9001, Render: ui.RenderMinio},
+	{Type: domain.ContainerTypeMariaDB, Strategy: &strategies.MariaDBStrategy{}, Icon: "\U0001F9AD", Color: "#C49A6C", Card: &database},
+	{Type: domain.ContainerTypeRabbitMQ, Strategy: &strategies.RabbitMQStrategy{}, Icon: "\U0001F407", Color: "#FF6600", WebPort: 15672, Card: &ui.CardSpec{Border: lipgloss.NormalBorder(), Headline: ui.VersionHeadline("RabbitMQ"), Fields: []string{"User", "Port"}}},
+	{Type: domain.ContainerTypeElasticsearch, Strategy: &strategies.ElasticsearchStrategy{}, Icon: "\U0001F50D", Color: "#FEC514", WebPort: 9200, Card: &ui.CardSpec{Border: lipgloss.ThickBorder(), Headline: ui.VersionHeadline("Elasticsearch"), Fields: port}},
+	{Type: domain.ContainerTypeKibana, Strategy: &strategies.KibanaStrategy{}, Icon: "\U0001F4C8", Color: "#E8478B", WebPort: 5601, Card: &ui.CardSpec{Border: lipgloss.RoundedBorder(), Headline: ui.VersionHeadline("Kibana"), Fields: port}},
+	{Type: domain.ContainerTypeJenkins, Strategy: &strategies.JenkinsStrategy{}, Icon: "\U0001F935", Color: "#D33833", WebPort: 8080, Card: &ui.CardSpec{Border: lipgloss.NormalBorder(), Headline: ui.VersionHeadline("Jenkins"), Fields: []string{"Admin User", "Port"}}},
+	{Type: domain.ContainerTypeWordPress, Strategy: &strategies.WordPressStrategy{}, Icon: "\U0001F4DD", Color: "#21759B", WebPort: 80, Render: ui.RenderWordPress},
+	{Type: domain.ContainerTypeVaultwarden, Strategy: &strategies.VaultwardenStrategy{}, Icon: "\U0001F510", Color: "#175DDC", WebPort: 80, Render: ui.RenderVaultwarden},
+	{Type: domain.ContainerTypeMosquitto, Strategy: &strategies.MosquittoStrategy{}, Icon: "\U0001F99F", Color: "#7B68C8", Card: &ui.CardSpec{Border: lipgloss.RoundedBorder(), Headline: ui.FixedHeadline("MQTT broker"), Fields: port}},
+	{Type: domain.ContainerTypePlex, Strategy: &strategies.PlexStrategy{}, Icon: "\U0001F3AC", Color: "#E5A00D", WebPort: 32400, Card: &mediaServer},
+	{Type: domain.ContainerTypeJellyfin, Strategy: &strategies.JellyfinStrategy{}, Icon: "\U0001F39E\uFE0F", Color: "#AA5CC3", WebPort: 8096, Card: &mediaServer},
+	{Type: domain.ContainerTypeHomeAssistant, Strategy: &strategies.HomeAssistantStrategy{}, Icon: "\U0001F3E0", Color: "#41BDF5", WebPort: 8123, Card: &ui.CardSpec{Border: lipgloss.RoundedBorder(), Headline: ui.FixedHeadline("Home automation"), Fields: port}},
+	{Type: domain.ContainerTypeSonarr, Strategy: &strategies.SonarrStrategy{}, Icon: "\U0001F4FA", Color: "#2EB8D8", WebPort: 8989, Card: &ui.CardSpec{Border: lipgloss.NormalBorder(), Headline: ui.FixedHeadline("TV series"), Fields: port}},
+	{Type: domain.ContainerTypeRadarr, Strategy: &strategies.RadarrStrategy{}, Icon: "\U0001F3A5", Color: "#FFC230", WebPort: 7878, Card: &ui.CardSpec{Border: lipgloss.NormalBorder(), Headline: ui.FixedHeadline("Movies"), Fields: port}},
+}
+
+func init() {
+	for _, p := range builtins {
+		MustRegister(p)
+	}
+}
@@ -0,0 +1,96 @@
+// Package plugin is the one place a container type is registered: how it is
+// recognised and inspected, and how the TUI and web dashboard draw it.
+//
+// A type is a Plugin passed to Register, usually from an init function:
+//
+//	func init() {
+//		plugin.MustRegister(plugin.Plugin{
+//			Type:     "gitea",
+//			Strategy: &GiteaStrategy{},
+//			Icon:     "\U0001F375",
+//			Color:    "#609926",
+//		})
+//	}
+//
+// The fetcher takes its strategies from Strategies and the UI is given each
+// type's card as it is registered.
+package plugin
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher/strategies"
+	"github.com/wosiu6/docky-go/internal/ui"
+)
+
+type Plugin struct {
+	Type domain.ContainerType
+	// Strategy matches the type by image and extracts its detail fields.
+	Strategy strategies.ContainerStrategy
+	// Icon and Color, as #RRGGBB, are the type's brand accent; themes may
+	// override the colour.
+	Icon  string
+	Color string
+	// WebPort is the container port the type serves its web interface on;
+	// the published side of it becomes the container's URL.
+	WebPort uint16
+	// Render draws the type's card. Without one, Card lays it out in the
+	// common style, and without either the type gets the generic card in
+	// its own icon and colour.
+	Render ui.CardRenderer
+	Card   *ui.CardSpec
+}
+
+var (
+	mu      sync.Mutex
+	plugins []Plugin
+)
+
+// Register adds p. Strategies are tried in registration order, so a type
+// whose images a broader strategy would also match must come first.
+func Register(p Plugin) error {
+	if p.Type == "" || p.Type == domain.ContainerTypeGeneric {
+		return fmt.Errorf("plugin: invalid type %q", p.Type)
+	}
+	if p.Strategy == nil {
+		return fmt.Errorf("plugin %s: no strategy", p.Type)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, existing := range plugins {
+		if existing.Type == p.Type {
+			return fmt.Errorf("plugin %s: already registered", p.Type)
+		}
+	}
+	plugins = append(plugins, p)
+	render := p.Render
+	if render == nil && p.Card != nil {
+		render = p.Card.Renderer(p.Type)
+	}
+	ui.RegisterCard(p.Type, ui.Appearance{Color: p.Color, Icon: p.Icon}, render)
+	return nil
+}
+
+func MustRegister(p Plugin) {
+	if err := Register(p); err != nil {
+		panic(err)
+	}
+}
+
+// All returns the registered plugins in registration order.
+func All() []Plugin {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Plugin(nil), plugins...)
+}
+
+// Strategies is the fetcher's view of the registry.
+func Strategies() []strategies.StrategyEntry {
+	var entries []strategies.StrategyEntry
+	for _, p := range All() {
+		entries = append(entries, strategies.StrategyEntry{Type: p.Type, Strategy: p.Strategy, WebPort: p.WebPort})
+	}
+	return entries
+}
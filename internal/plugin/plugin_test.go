@@ -0,0 +1,137 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher/strategies"
+	"github.com/wosiu6/docky-go/internal/ui"
+)
+
+func TestRegistry_NotEmpty(t *testing.T) {
+	entries := All()
+	if len(entries) == 0 {
+		t.Fatal("registry should not be empty")
+	}
+	foundPostgres := false
+	foundRedis := false
+	for _, e := range entries {
+		if e.Type == "postgresql" {
+			foundPostgres = true
+		}
+		if e.Type == "redis" {
+			foundRedis = true
+		}
+	}
+	if !foundPostgres {
+		t.Error("postgres strategy missing")
+	}
+	if !foundRedis {
+		t.Error("redis strategy missing")
+	}
+}
+
+func TestRegistryUniqueness(t *testing.T) {
+	entries := All()
+	if len(entries) == 0 {
+		t.Fatalf("registry should not be empty")
+	}
+	seen := map[string]struct{}{}
+	for _, e := range entries {
+		if _, ok := seen[string(e.Type)]; ok {
+			t.Fatalf("duplicate container type in registry: %s", e.Type)
+		}
+		seen[string(e.Type)] = struct{}{}
+		if e.Strategy == nil {
+			t.Fatalf("nil strategy for type %s", e.Type)
+		}
+	}
+}
+
+var sampleImages = map[string]string{
+	"postgres":                     "postgresql",
+	"redis:7":                      "redis",
+	"mysql:8":                      "mysql",
+	"mongo:6":                      "mongodb",
+	"grafana/grafana:latest":       "grafana",
+	"prom/prometheus":              "prometheus",
+	"nextcloud:stable":             "nextcloud",
+	"minio/minio:latest":           "minio",
+	"mariadb:10":                   "mariadb",
+	"rabbitmq:3-management":        "rabbitmq",
+	"elasticsearch:8":              "elasticsearch",
+	"kibana:8":                     "kibana",
+	"jenkins/jenkins:lts":          "jenkins",
+	"wordpress:php8":               "wordpress",
+	"vaultwarden/server:latest":    "vaultwarden",
+	"eclipse-mosquitto:latest":     "mosquitto",
+	"plexinc/pms-docker":           "plex",
+	"jellyfin/jellyfin":            "jellyfin",
+	"homeassistant/home-assistant": "homeassistant",
+	"sonarr:latest":                "sonarr",
+	"radarr:latest":                "radarr",
+	"traefik:v2":                   "traefik",
+	"owncloud/server":              "owncloud",
+	"immich-server:latest":         "immich",
+}
+
+func TestStrategyMatchSamples(t *testing.T) {
+	entries := Strategies()
+	for img, expectedType := range sampleImages {
+		matched := false
+		for _, e := range entries {
+			if e.Strategy.Match(img) {
+				if string(e.Type) != expectedType {
+					t.Errorf("image %s matched wrong type %s want %s", img, e.Type, expectedType)
+				}
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			if expectedType != "" {
+				t.Errorf("image %s expected type %s but no strategy matched", img, expectedType)
+			}
+		}
+	}
+}
+
+func TestRegisterRejects(t *testing.T) {
+	cases := map[string]Plugin{
+		"empty type":   {Strategy: &strategies.RedisStrategy{}},
+		"generic type": {Type: domain.ContainerTypeGeneric, Strategy: &strategies.RedisStrategy{}},
+		"no strategy":  {Type: "gitea"},
+		"duplicate":    {Type: domain.ContainerTypeRedis, Strategy: &strategies.RedisStrategy{}},
+	}
+	for name, p := range cases {
+		if err := Register(p); err == nil {
+			t.Errorf("%s: registered", name)
+		}
+	}
+}
+
+func TestRegisterFeedsUI(t *testing.T) {
+	if err := Register(Plugin{Type: "gitea", Strategy: &strategies.RedisStrategy{}, Icon: "G", Color: "#609926"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { plugins = plugins[:len(plugins)-1] }()
+	if look := ui.AppearanceFor("gitea"); look.Icon != "G" || look.Color != "#609926" {
+		t.Errorf("appearance = %+v", look)
+	}
+	if _, ok := ui.Palette().Types["gitea"]; !ok {
+		t.Error("palette misses the plugin type")
+	}
+	if entries := Strategies(); entries[len(entries)-1].Type != "gitea" {
+		t.Errorf("last strategy = %s", entries[len(entries)-1].Type)
+	}
+}
+
+func TestStrategiesCarryWebPort(t *testing.T) {
+	ports := map[domain.ContainerType]uint16{}
+	for _, e := range Strategies() {
+		ports[e.Type] = e.WebPort
+	}
+	if ports[domain.ContainerTypeGrafana] != 3000 || ports[domain.ContainerTypePostgreSQL] != 0 {
+		t.Fatalf("unexpected web ports: %v", ports)
+	}
+}
@@ -0,0 +1,193 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/docker"
+)
+
+type response struct {
+	data json.RawMessage
+	err  string
+}
+
+type frame struct {
+	offset  time.Duration
+	list    response
+	inspect map[string]response
+	stats   map[string]response
+}
+
+// Player is a docker.DockerClient that serves a recording. The frame shown
+// is chosen by wall-clock time since the first list call, scaled by speed,
+// so a refresh loop sees the session unfold as it was recorded.
+type Player struct {
+	Header Header
+
+	frames []frame
+	speed  float64
+	loop   bool
+	now    func() time.Time
+
+	mu    sync.Mutex
+	start time.Time
+	cur   int
+}
+
+type PlayerOption func(*Player)
+
+// WithSpeed plays the recording faster (>1) or slower (<1) than recorded.
+func WithSpeed(s float64) PlayerOption { return func(p *Player) { p.speed = s } }
+
+// WithLoop starts over after the last frame instead of holding it.
+func WithLoop(loop bool) PlayerOption { return func(p *Player) { p.loop = loop } }
+
+func NewPlayer(r io.Reader, opts ...PlayerOption) (*Player, error) {
+	p := &Player{speed: 1, now: time.Now}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.speed <= 0 {
+		return nil, fmt.Errorf("replay speed must be positive, got %g", p.speed)
+	}
+	if err := p.load(r); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Player) load(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	var first time.Time
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return fmt.Errorf("recording line %d: %w", line, err)
+		}
+		resp := response{data: e.Data, err: e.Error}
+		switch e.Op {
+		case OpHeader:
+			if err := json.Unmarshal(e.Data, &p.Header); err != nil {
+				return fmt.Errorf("recording header: %w", err)
+			}
+			if p.Header.Version != Version {
+				return fmt.Errorf("unsupported recording version %d", p.Header.Version)
+			}
+		case OpList:
+			if len(p.frames) == 0 {
+				first = e.At
+			}
+			p.frames = append(p.frames, frame{offset: e.At.Sub(first), list: resp, inspect: map[string]response{}, stats: map[string]response{}})
+		case OpInspect, OpStats:
+			if len(p.frames) == 0 {
+				continue
+			}
+			f := &p.frames[len(p.frames)-1]
+			if e.Op == OpInspect {
+				f.inspect[e.ID] = resp
+			} else {
+				f.stats[e.ID] = resp
+			}
+		default:
+			return fmt.Errorf("recording line %d: unknown op %q", line, e.Op)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	if len(p.frames) == 0 {
+		return errors.New("recording contains no container listings")
+	}
+	return nil
+}
+
+// Frames is the number of refreshes in the recording.
+func (p *Player) Frames() int { return len(p.frames) }
+
+// Done reports whether playback has reached the last frame; it never is when
+// looping.
+func (p *Player) Done() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.loop && p.cur == len(p.frames)-1
+}
+
+// advance picks the frame for the current time. Only list calls move the
+// cursor so every inspect and stats call of one refresh reads the same frame.
+func (p *Player) advance() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	if p.start.IsZero() {
+		p.start = now
+	}
+	pos := time.Duration(float64(now.Sub(p.start)) * p.speed)
+	last := p.frames[len(p.frames)-1].offset
+	if p.loop && len(p.frames) > 1 && last > 0 {
+		// leave one average refresh interval between the last and first frame
+		period := last + last/time.Duration(len(p.frames)-1)
+		pos %= period
+	}
+	p.cur = 0
+	for i, f := range p.frames {
+		if f.offset > pos {
+			break
+		}
+		p.cur = i
+	}
+	return p.cur
+}
+
+func (p *Player) frame() frame {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.frames[p.cur]
+}
+
+func (p *Player) Ping(ctx context.Context) error { return nil }
+
+func (p *Player) ListContainers(ctx context.Context) ([]map[string]any, error) {
+	f := p.frames[p.advance()]
+	if f.list.err != "" {
+		return nil, errors.New(f.list.err)
+	}
+	var out []map[string]any
+	if err := json.Unmarshal(f.list.data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (p *Player) ContainerStats(ctx context.Context, id string, dest any) error {
+	return decode("stats", id, p.frame().stats, dest)
+}
+
+func (p *Player) ContainerInspect(ctx context.Context, id string, dest any) error {
+	return decode("inspect", id, p.frame().inspect, dest)
+}
+
+func decode(op, id string, responses map[string]response, dest any) error {
+	r, ok := responses[id]
+	if !ok {
+		return &docker.HTTPError{Op: op, Status: http.StatusNotFound, Body: "not in recording"}
+	}
+	if r.err != "" {
+		return errors.New(r.err)
+	}
+	return json.Unmarshal(r.data, dest)
+}
+
+func (p *Player) GetHttpClient() *http.Client { return nil }
+func (p *Player) GetUrl() string              { return "replay://" }
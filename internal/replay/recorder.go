@@ -0,0 +1,151 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+// Recorder is a docker.DockerClient that forwards every call and appends the
+// raw response of the list, inspect and stats calls to w. Actions pass
+// through but are not recorded; a replay shows their effect through the list
+// and inspect calls that follow. Network listings, disk usage and prunes
+// pass through unrecorded too, so the networks and disk screens are
+// unavailable in a replay.
+type Recorder struct {
+	docker.DockerClient
+
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+	now func() time.Time
+}
+
+func NewRecorder(c docker.DockerClient, w io.Writer, host string) (*Recorder, error) {
+	r := &Recorder{DockerClient: c, enc: json.NewEncoder(w), now: time.Now}
+	data, _ := json.Marshal(Header{Version: Version, Host: host})
+	if err := r.enc.Encode(Entry{Op: OpHeader, Data: data}); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Err reports the first write failure; recording stops after it but the
+// wrapped client keeps working.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) write(op, id string, data []byte, err error) {
+	e := Entry{At: r.now().UTC(), Op: op, ID: id, Data: data}
+	if err != nil {
+		e.Data = nil
+		e.Error = err.Error()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = r.enc.Encode(e)
+	}
+}
+
+func (r *Recorder) ListContainers(ctx context.Context) ([]map[string]any, error) {
+	out, err := r.DockerClient.ListContainers(ctx)
+	data, _ := json.Marshal(out)
+	r.write(OpList, "", data, err)
+	return out, err
+}
+
+func (r *Recorder) ContainerStats(ctx context.Context, id string, dest any) error {
+	var raw json.RawMessage
+	err := r.DockerClient.ContainerStats(ctx, id, &raw)
+	return r.capture(OpStats, id, raw, err, dest)
+}
+
+func (r *Recorder) ContainerInspect(ctx context.Context, id string, dest any) error {
+	var raw json.RawMessage
+	err := r.DockerClient.ContainerInspect(ctx, id, &raw)
+	return r.capture(OpInspect, id, raw, err, dest)
+}
+
+// capture records a single-object response and decodes it into dest the way
+// the real client would have.
+func (r *Recorder) capture(op, id string, raw json.RawMessage, err error, dest any) error {
+	r.write(op, id, raw, err)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+func (r *Recorder) ContainerAction(ctx context.Context, id string, action docker.Action) error {
+	ac, ok := r.DockerClient.(docker.ActionClient)
+	if !ok {
+		return errors.New("container actions are not supported by this client")
+	}
+	return ac.ContainerAction(ctx, id, action)
+}
+
+func (r *Recorder) ListNetworks(ctx context.Context, dest any) error {
+	nc, ok := r.DockerClient.(docker.NetworkClient)
+	if !ok {
+		return fetcher.ErrNoNetworks
+	}
+	return nc.ListNetworks(ctx, dest)
+}
+
+func (r *Recorder) disk() (docker.DiskClient, error) {
+	dc, ok := r.DockerClient.(docker.DiskClient)
+	if !ok {
+		return nil, fetcher.ErrNoDisk
+	}
+	return dc, nil
+}
+
+func (r *Recorder) ListImages(ctx context.Context, dest any) error {
+	dc, err := r.disk()
+	if err != nil {
+		return err
+	}
+	return dc.ListImages(ctx, dest)
+}
+
+func (r *Recorder) ListVolumes(ctx context.Context, dest any) error {
+	dc, err := r.disk()
+	if err != nil {
+		return err
+	}
+	return dc.ListVolumes(ctx, dest)
+}
+
+func (r *Recorder) DiskUsage(ctx context.Context, dest any) error {
+	dc, err := r.disk()
+	if err != nil {
+		return err
+	}
+	return dc.DiskUsage(ctx, dest)
+}
+
+func (r *Recorder) Prune(ctx context.Context, target docker.PruneTarget) (docker.PruneReport, error) {
+	pc, ok := r.DockerClient.(docker.PruneClient)
+	if !ok {
+		return docker.PruneReport{}, errors.New("pruning is not supported by this client")
+	}
+	return pc.Prune(ctx, target)
+}
+
+func (r *Recorder) RemoveVolume(ctx context.Context, name string) error {
+	pc, ok := r.DockerClient.(docker.PruneClient)
+	if !ok {
+		return errors.New("pruning is not supported by this client")
+	}
+	return pc.RemoveVolume(ctx, name)
+}
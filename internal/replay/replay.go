@@ -0,0 +1,41 @@
+// Package replay records the raw Docker API responses the fetcher sees and
+// plays them back through a fake docker.DockerClient.
+//
+// A recording is JSON Lines. The first line is a header, every following line
+// is one API call:
+//
+//	{"op":"header","data":{"version":1,"host":"..."}}
+//	{"at":"2024-05-01T03:00:00.1Z","op":"list","data":[...]}
+//	{"at":"2024-05-01T03:00:00.2Z","op":"inspect","id":"abc","data":{...}}
+//	{"at":"2024-05-01T03:00:00.2Z","op":"stats","id":"abc","error":"..."}
+//
+// Each list call starts a new frame; the inspect and stats calls after it
+// belong to that frame.
+package replay
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const Version = 1
+
+const (
+	OpHeader  = "header"
+	OpList    = "list"
+	OpInspect = "inspect"
+	OpStats   = "stats"
+)
+
+type Entry struct {
+	At    time.Time       `json:"at,omitzero"`
+	Op    string          `json:"op"`
+	ID    string          `json:"id,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+type Header struct {
+	Version int    `json:"version"`
+	Host    string `json:"host"`
+}
@@ -0,0 +1,152 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+	"github.com/wosiu6/docky-go/internal/plugin"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func openFixture(t *testing.T, opts ...PlayerOption) (*Player, *fakeClock) {
+	t.Helper()
+	f, err := os.Open("testdata/session.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	p, err := NewPlayer(f, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	p.now = clock.now
+	return p, clock
+}
+
+func TestPlayerDrivesFetcher(t *testing.T) {
+	p, clock := openFixture(t)
+	if p.Header.Host != "fixture" || p.Frames() != 3 {
+		t.Fatalf("header %+v, %d frames", p.Header, p.Frames())
+	}
+	f := fetcher.NewWithConfig(p, fetcher.FetcherConfig{Strategies: plugin.Strategies()})
+	ctx := context.Background()
+
+	items, err := f.DomainContainers(ctx)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("frame 0: %v, %d items", err, len(items))
+	}
+	c := items[0]
+	if c.Type != domain.ContainerTypePostgreSQL || c.MemoryMB != 128 || c.PIDs != 7 || c.ComposeProject() != "shop" {
+		t.Errorf("frame 0: %+v", c)
+	}
+	if c.Details == nil {
+		t.Fatal("frame 0 has no details")
+	}
+	if f, _ := domain.LookupField(c.Details.DetailFields(), "Database"); f.Text != "orders" {
+		t.Errorf("frame 0 details: %v", c.Details.DetailFields())
+	}
+
+	clock.t = clock.t.Add(time.Second)
+	items, _ = f.DomainContainers(ctx)
+	if c := items[0]; c.CPUPercent != 40 || c.MemoryMB != 256 {
+		t.Errorf("frame 1: cpu %v mem %d", c.CPUPercent, c.MemoryMB)
+	}
+
+	clock.t = clock.t.Add(time.Second)
+	items, _ = f.DomainContainers(ctx)
+	if c := items[0]; c.Status != "exited" || !c.Lifecycle.OOMKilled || c.Lifecycle.ExitCode != 137 {
+		t.Errorf("frame 2: %+v", c)
+	}
+	if !p.Done() {
+		t.Error("expected playback to be done after the last frame")
+	}
+}
+
+func TestPlayerSpeedAndLoop(t *testing.T) {
+	p, clock := openFixture(t, WithSpeed(4), WithLoop(true))
+	ctx := context.Background()
+	want := []int{0, 0, 1, 1, 2, 2, 0}
+	for i, w := range want {
+		p.ListContainers(ctx)
+		if p.cur != w {
+			t.Errorf("step %d: frame %d, want %d", i, p.cur, w)
+		}
+		clock.t = clock.t.Add(125 * time.Millisecond)
+	}
+	if p.Done() {
+		t.Error("looping playback is never done")
+	}
+}
+
+func TestPlayerRejectsBadInput(t *testing.T) {
+	for name, in := range map[string]string{
+		"empty":   "",
+		"version": `{"op":"header","data":{"version":99}}`,
+		"op":      `{"op":"bogus"}`,
+	} {
+		if _, err := NewPlayer(strings.NewReader(in)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := NewPlayer(strings.NewReader(""), WithSpeed(0)); err == nil {
+		t.Error("expected error for zero speed")
+	}
+}
+
+type fakeDocker struct{}
+
+func (fakeDocker) Ping(ctx context.Context) error { return nil }
+func (fakeDocker) ListContainers(ctx context.Context) ([]map[string]any, error) {
+	return []map[string]any{{"Id": "r1", "Names": []any{"/cache"}, "Image": "redis:7", "State": "running"}}, nil
+}
+func (fakeDocker) ContainerStats(ctx context.Context, id string, dest any) error {
+	return errors.New("stats unavailable")
+}
+func (fakeDocker) ContainerInspect(ctx context.Context, id string, dest any) error {
+	return json.Unmarshal([]byte(`{"RestartCount":2,"State":{"Status":"running"}}`), dest)
+}
+func (fakeDocker) GetHttpClient() *http.Client { return nil }
+func (fakeDocker) GetUrl() string              { return "fake" }
+
+func TestRecordThenReplay(t *testing.T) {
+	var buf bytes.Buffer
+	rec, err := NewRecorder(fakeDocker{}, &buf, "box")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := fetcher.New(rec).DomainContainers(context.Background())
+	if err != nil || rec.Err() != nil {
+		t.Fatalf("record: %v / %v", err, rec.Err())
+	}
+	if recorded[0].Lifecycle.RestartCount != 2 {
+		t.Fatalf("recorder did not decode inspect: %+v", recorded[0].Lifecycle)
+	}
+
+	p, err := NewPlayer(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := fetcher.New(p).DomainContainers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Header.Host != "box" || len(replayed) != 1 || replayed[0].Name() != "cache" || replayed[0].Lifecycle.RestartCount != 2 || replayed[0].Type != recorded[0].Type {
+		t.Errorf("replayed %+v, recorded %+v", replayed, recorded)
+	}
+	if err := p.ContainerStats(context.Background(), "r1", &struct{}{}); err == nil || err.Error() != "stats unavailable" {
+		t.Errorf("recorded error not replayed: %v", err)
+	}
+}
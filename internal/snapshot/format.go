@@ -0,0 +1,97 @@
+package snapshot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var Formats = []string{"json", "yaml", "csv", "table"}
+
+func Write(w io.Writer, s Snapshot, format string) error {
+	switch strings.ToLower(format) {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	case "yaml", "yml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(s); err != nil {
+			return err
+		}
+		return enc.Close()
+	case "csv":
+		return writeCSV(w, s)
+	case "table":
+		return writeTable(w, s)
+	default:
+		return fmt.Errorf("unknown format %q (want one of %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+var csvHeader = []string{
+	"version", "id", "name", "image", "type", "state", "status", "health", "compose_project",
+	"cpu_percent", "memory_mb", "memory_limit_mb", "pids", "net_rx_bytes", "net_tx_bytes",
+	"block_read_bytes", "block_write_bytes", "restart_count", "exit_code", "oom_killed",
+	"restart_storm", "started_at", "finished_at", "details",
+}
+
+// writeCSV flattens details into one "name=value;name=value" column, in the
+// strategy's order, so the column set stays fixed across container types.
+func writeCSV(w io.Writer, s Snapshot) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	u := func(v uint64) string { return strconv.FormatUint(v, 10) }
+	for _, c := range s.Containers {
+		row := []string{
+			strconv.Itoa(s.Version), c.ID, c.Name, c.Image, c.Type, c.State, c.Status, c.Health, c.ComposeProject,
+			strconv.FormatFloat(c.CPUPercent, 'f', 2, 64), u(c.MemoryMB), u(c.MemoryLimitMB), u(c.PIDs),
+			u(c.NetRxBytes), u(c.NetTxBytes), u(c.BlockReadBytes), u(c.BlockWriteBytes),
+			strconv.Itoa(c.RestartCount), strconv.Itoa(c.ExitCode), strconv.FormatBool(c.OOMKilled),
+			strconv.FormatBool(c.RestartStorm), timeField(c.StartedAt), timeField(c.FinishedAt), joinDetails(c.Details, ";"),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeTable(w io.Writer, s Snapshot) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tSTATE\tHEALTH\tCPU %\tMEM\tRESTARTS\tIMAGE\tDETAILS")
+	for _, c := range s.Containers {
+		mem := fmt.Sprintf("%d MB", c.MemoryMB)
+		if c.MemoryLimitMB > 0 {
+			mem = fmt.Sprintf("%d / %d MB", c.MemoryMB, c.MemoryLimitMB)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%.1f\t%s\t%d\t%s\t%s\n", c.Name, c.Type, c.State, c.Health, c.CPUPercent, mem, c.RestartCount, c.Image, joinDetails(c.Details, ", "))
+	}
+	return tw.Flush()
+}
+
+func joinDetails(details []Detail, sep string) string {
+	parts := make([]string, len(details))
+	for i, d := range details {
+		parts[i] = d.Name + "=" + d.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+func timeField(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
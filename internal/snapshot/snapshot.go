@@ -0,0 +1,215 @@
+// Package snapshot captures the current containers once and writes them in a
+// stable, versioned schema for scripts and cron jobs.
+//
+// Schema version 2:
+//
+//	version            int      always 2 for this layout
+//	generated_at       RFC 3339 timestamp of the capture
+//	host               daemon host name
+//	containers[]:
+//	  id, name, image, type, state, status, health   strings; health is "none" without a healthcheck
+//	  compose_project                                string, empty outside compose
+//	  url                                            string, the web interface, omitted when unknown
+//	  hosts                                          strings, Traefik hostnames routed to it, omitted when none
+//	  cpu_percent                                    float
+//	  memory_mb, memory_limit_mb, pids               int
+//	  net_rx_bytes, net_tx_bytes                     int
+//	  block_read_bytes, block_write_bytes            int
+//	  restart_count, exit_code                       int
+//	  oom_killed, restart_storm                      bool
+//	  started_at, finished_at                        RFC 3339, omitted when unknown
+//	  labels                                         map of string to string
+//	  details[]: name, kind, value, unit             strategy detail fields in the strategy's order;
+//	                                                 value is a number for int, bytes, duration
+//	                                                 and percent, a bool for bool and a string
+//	                                                 otherwise; unit is omitted when empty
+//	  alerts[]: rule, severity, value, since         firing alerts, omitted when none
+//
+// New fields may be added within a version; renames or removals bump it.
+// Version 1 had details as a map of field name to display text; version 2
+// made it the typed list above, keeping numbers as numbers. CSV keeps its
+// name=value column.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+const Version = 2
+
+type Snapshot struct {
+	Version     int         `json:"version" yaml:"version"`
+	GeneratedAt time.Time   `json:"generated_at" yaml:"generated_at"`
+	Host        string      `json:"host" yaml:"host"`
+	Containers  []Container `json:"containers" yaml:"containers"`
+}
+
+type Container struct {
+	ID              string            `json:"id" yaml:"id"`
+	Name            string            `json:"name" yaml:"name"`
+	Image           string            `json:"image" yaml:"image"`
+	Type            string            `json:"type" yaml:"type"`
+	State           string            `json:"state" yaml:"state"`
+	Status          string            `json:"status" yaml:"status"`
+	Health          string            `json:"health" yaml:"health"`
+	ComposeProject  string            `json:"compose_project" yaml:"compose_project"`
+	URL             string            `json:"url,omitempty" yaml:"url,omitempty"`
+	Hosts           []string          `json:"hosts,omitempty" yaml:"hosts,omitempty"`
+	CPUPercent      float64           `json:"cpu_percent" yaml:"cpu_percent"`
+	MemoryMB        uint64            `json:"memory_mb" yaml:"memory_mb"`
+	MemoryLimitMB   uint64            `json:"memory_limit_mb" yaml:"memory_limit_mb"`
+	PIDs            uint64            `json:"pids" yaml:"pids"`
+	NetRxBytes      uint64            `json:"net_rx_bytes" yaml:"net_rx_bytes"`
+	NetTxBytes      uint64            `json:"net_tx_bytes" yaml:"net_tx_bytes"`
+	BlockReadBytes  uint64            `json:"block_read_bytes" yaml:"block_read_bytes"`
+	BlockWriteBytes uint64            `json:"block_write_bytes" yaml:"block_write_bytes"`
+	RestartCount    int               `json:"restart_count" yaml:"restart_count"`
+	ExitCode        int               `json:"exit_code" yaml:"exit_code"`
+	OOMKilled       bool              `json:"oom_killed" yaml:"oom_killed"`
+	RestartStorm    bool              `json:"restart_storm" yaml:"restart_storm"`
+	StartedAt       *time.Time        `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	FinishedAt      *time.Time        `json:"finished_at,omitempty" yaml:"finished_at,omitempty"`
+	Labels          map[string]string `json:"labels" yaml:"labels"`
+	Details         []Detail          `json:"details" yaml:"details"`
+	Alerts          []Alert           `json:"alerts,omitempty" yaml:"alerts,omitempty"`
+}
+
+// Detail is a domain.Field in exported form. Kind is one of string, int,
+// bytes, duration, percent, url, bool and secret; bytes are counted in bytes
+// and durations in seconds.
+type Detail struct {
+	Name  string `json:"name" yaml:"name"`
+	Kind  string `json:"kind" yaml:"kind"`
+	Value any    `json:"value" yaml:"value"`
+	Unit  string `json:"unit,omitempty" yaml:"unit,omitempty"`
+}
+
+var kindNames = map[domain.FieldKind]string{
+	domain.FieldString:   "string",
+	domain.FieldInt:      "int",
+	domain.FieldBytes:    "bytes",
+	domain.FieldDuration: "duration",
+	domain.FieldPercent:  "percent",
+	domain.FieldURL:      "url",
+	domain.FieldBool:     "bool",
+	domain.FieldSecret:   "secret",
+}
+
+func detailFromField(f domain.Field) Detail {
+	d := Detail{Name: f.Name, Kind: kindNames[f.Kind], Unit: f.Unit}
+	switch {
+	case f.Kind == domain.FieldBool:
+		d.Value = f.Bool()
+	case f.Numeric():
+		d.Value = f.Num
+	default:
+		d.Value = f.Text
+	}
+	return d
+}
+
+// String formats the value the way the TUI does. It also accepts the
+// float64, int and bool values a JSON or YAML decoder produces.
+func (d Detail) String() string {
+	f := domain.Field{Name: d.Name, Unit: d.Unit}
+	for k, name := range kindNames {
+		if name == d.Kind {
+			f.Kind = k
+		}
+	}
+	switch v := d.Value.(type) {
+	case bool:
+		f.Kind = domain.FieldBool
+		if v {
+			f.Num = 1
+		}
+	case float64:
+		f.Num = v
+	case int:
+		f.Num = float64(v)
+	case string:
+		f.Text = v
+	default:
+		return fmt.Sprint(v)
+	}
+	return f.String()
+}
+
+type Alert struct {
+	Rule     string    `json:"rule" yaml:"rule"`
+	Severity string    `json:"severity" yaml:"severity"`
+	Value    string    `json:"value" yaml:"value"`
+	Since    time.Time `json:"since" yaml:"since"`
+}
+
+type Source interface {
+	FetchAll(ctx context.Context) ([]domain.Container, error)
+}
+
+// Take fetches twice, sampleDelay apart, because CPU usage is a delta between
+// two readings and the first fetch of a fresh Fetcher always reports zero.
+func Take(ctx context.Context, src Source, host string, sampleDelay time.Duration) (Snapshot, error) {
+	containers, err := src.FetchAll(ctx)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if sampleDelay > 0 {
+		select {
+		case <-ctx.Done():
+			return Snapshot{}, ctx.Err()
+		case <-time.After(sampleDelay):
+		}
+		if containers, err = src.FetchAll(ctx); err != nil {
+			return Snapshot{}, err
+		}
+	}
+	return New(containers, host, time.Now()), nil
+}
+
+func New(containers []domain.Container, host string, now time.Time) Snapshot {
+	s := Snapshot{Version: Version, GeneratedAt: now.UTC(), Host: host, Containers: make([]Container, 0, len(containers))}
+	for _, c := range containers {
+		s.Containers = append(s.Containers, FromDomain(c))
+	}
+	return s
+}
+
+func FromDomain(c domain.Container) Container {
+	health := "none"
+	if c.Health != nil {
+		health = string(c.Health.Status)
+	}
+	out := Container{
+		ID: c.ID, Name: c.Name(), Image: c.Image, Type: string(c.Type),
+		State: domain.EffectiveState(c.Status, c.Health), Status: c.StatusText, Health: health,
+		ComposeProject: c.ComposeProject(), URL: c.URL, Hosts: domain.RouteHosts(c.Routes), CPUPercent: c.CPUPercent,
+		MemoryMB: c.MemoryMB, MemoryLimitMB: c.MemoryLimitMB, PIDs: c.PIDs,
+		NetRxBytes: c.NetRxBytes, NetTxBytes: c.NetTxBytes,
+		BlockReadBytes: c.BlockReadBytes, BlockWriteBytes: c.BlockWriteBytes,
+		RestartCount: c.Lifecycle.RestartCount, ExitCode: c.Lifecycle.ExitCode,
+		OOMKilled: c.Lifecycle.OOMKilled, RestartStorm: c.Lifecycle.RestartStorm,
+		Labels: c.Labels, Details: []Detail{},
+	}
+	if out.Labels == nil {
+		out.Labels = map[string]string{}
+	}
+	if t := c.Lifecycle.StartedAt; !t.IsZero() {
+		out.StartedAt = &t
+	}
+	if t := c.Lifecycle.FinishedAt; !t.IsZero() {
+		out.FinishedAt = &t
+	}
+	if c.Details != nil {
+		for _, f := range c.Details.DetailFields() {
+			out.Details = append(out.Details, detailFromField(f))
+		}
+	}
+	for _, a := range c.Alerts {
+		out.Alerts = append(out.Alerts, Alert{Rule: a.Rule, Severity: a.Severity, Value: a.Value, Since: a.Since})
+	}
+	return out
+}
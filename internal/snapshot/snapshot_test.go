@@ -0,0 +1,154 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+type fakeDetails []domain.Field
+
+func (f fakeDetails) DetailFields() []domain.Field { return f }
+
+type fakeSource struct{ calls int }
+
+func (f *fakeSource) FetchAll(ctx context.Context) ([]domain.Container, error) {
+	f.calls++
+	return []domain.Container{{
+		ID: "abc", Names: []string{"/db"}, Image: "postgres:16", Type: domain.ContainerTypePostgreSQL,
+		Status: "running", StatusText: "Up 2 hours", CPUPercent: float64(f.calls), MemoryMB: 64,
+		Labels:  map[string]string{domain.LabelComposeProject: "shop"},
+		Details: fakeDetails{domain.StringField("Database", "app"), domain.IntField("Port", 5432)},
+	}}, nil
+}
+
+func TestTake_SamplesTwice(t *testing.T) {
+	src := &fakeSource{}
+	s, err := Take(context.Background(), src, "box", time.Millisecond)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if src.calls != 2 || s.Containers[0].CPUPercent != 2 {
+		t.Fatalf("expected the second sample to be used, calls=%d cpu=%v", src.calls, s.Containers[0].CPUPercent)
+	}
+	if s.Version != Version || s.Host != "box" {
+		t.Errorf("unexpected header %#v", s)
+	}
+}
+
+func TestWrite_JSONSchema(t *testing.T) {
+	s, _ := Take(context.Background(), &fakeSource{}, "box", 0)
+	var buf bytes.Buffer
+	if err := Write(&buf, s, "json"); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if got["version"] != float64(Version) {
+		t.Errorf("missing version: %v", got["version"])
+	}
+	c := got["containers"].([]any)[0].(map[string]any)
+	if c["name"] != "db" || c["health"] != "none" || c["compose_project"] != "shop" {
+		t.Errorf("unexpected container: %v", c)
+	}
+	d := c["details"].([]any)
+	if len(d) != 2 {
+		t.Fatalf("unexpected details: %v", d)
+	}
+	want := []map[string]any{
+		{"name": "Database", "kind": "string", "value": "app"},
+		{"name": "Port", "kind": "int", "value": float64(5432)},
+	}
+	for i, w := range want {
+		got := d[i].(map[string]any)
+		for k, v := range w {
+			if got[k] != v {
+				t.Errorf("details[%d].%s = %v, want %v", i, k, got[k], v)
+			}
+		}
+	}
+	if _, ok := c["started_at"]; ok {
+		t.Errorf("unknown start time should be omitted")
+	}
+}
+
+func TestWrite_YAML(t *testing.T) {
+	s, _ := Take(context.Background(), &fakeSource{}, "box", 0)
+	var buf bytes.Buffer
+	if err := Write(&buf, s, "yaml"); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	var got Snapshot
+	if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid yaml: %v", err)
+	}
+	if got.Version != Version || len(got.Containers[0].Details) != 2 || got.Containers[0].Details[1].String() != "5432" {
+		t.Errorf("unexpected round trip: %#v", got)
+	}
+}
+
+func TestWrite_CSVAndTable(t *testing.T) {
+	s, _ := Take(context.Background(), &fakeSource{}, "box", 0)
+	var buf bytes.Buffer
+	if err := Write(&buf, s, "csv"); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if len(rows) != 2 || len(rows[1]) != len(csvHeader) {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+	if last := rows[1][len(rows[1])-1]; last != "Database=app;Port=5432" {
+		t.Errorf("unexpected details column %q", last)
+	}
+	buf.Reset()
+	if err := Write(&buf, s, "table"); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "NAME") || !strings.Contains(buf.String(), "db") {
+		t.Errorf("unexpected table:\n%s", buf.String())
+	}
+	if err := Write(&buf, s, "xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestFromDomain_DetailValues(t *testing.T) {
+	c := FromDomain(domain.Container{ID: "x", Details: fakeDetails{
+		domain.BytesField("Cache", 2_000_000),
+		domain.DurationField("Uptime", 90*time.Second),
+		domain.BoolField("Replica", false),
+		domain.IntField("Players", 3).WithUnit("players"),
+	}})
+	want := []Detail{
+		{Name: "Cache", Kind: "bytes", Value: float64(2_000_000)},
+		{Name: "Uptime", Kind: "duration", Value: float64(90)},
+		{Name: "Replica", Kind: "bool", Value: false},
+		{Name: "Players", Kind: "int", Value: float64(3), Unit: "players"},
+	}
+	if len(c.Details) != len(want) {
+		t.Fatalf("got %d details, want %d", len(c.Details), len(want))
+	}
+	for i, w := range want {
+		if c.Details[i] != w {
+			t.Errorf("details[%d] = %#v, want %#v", i, c.Details[i], w)
+		}
+	}
+	if s := c.Details[0].String(); s != "2.0MB" {
+		t.Errorf("bytes formatted as %q", s)
+	}
+	if s := c.Details[3].String(); s != "3 players" {
+		t.Errorf("int formatted as %q", s)
+	}
+}
@@ -0,0 +1,97 @@
+// Package traefik reads router state from the Traefik API, which Traefik
+// serves on its dashboard port when started with --api.
+package traefik
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+type Client struct {
+	// URL is the API's base address, e.g. http://localhost:8080.
+	URL    string
+	Client *http.Client
+}
+
+type router struct {
+	Name        string    `json:"name"`
+	Provider    string    `json:"provider"`
+	Rule        string    `json:"rule"`
+	Service     string    `json:"service"`
+	EntryPoints []string  `json:"entryPoints"`
+	Status      string    `json:"status"`
+	Error       []string  `json:"error"`
+	TLS         *struct{} `json:"tls"`
+}
+
+// Routers lists the HTTP routers Traefik has loaded, from every provider.
+func (c *Client) Routers(ctx context.Context) ([]domain.Route, error) {
+	var routers []router
+	if err := c.get(ctx, "/api/http/routers?per_page=1000", &routers); err != nil {
+		return nil, err
+	}
+	routes := make([]domain.Route, 0, len(routers))
+	for _, r := range routers {
+		name, provider, _ := strings.Cut(r.Name, "@")
+		if r.Provider != "" {
+			provider = r.Provider
+		}
+		route := domain.Route{
+			Router: name, Provider: provider, Service: r.Service, Entrypoints: r.EntryPoints,
+			TLS: r.TLS != nil, Status: r.Status, Errors: r.Error,
+		}
+		route.SetRule(r.Rule)
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+func (c *Client) Overview(ctx context.Context) (domain.TraefikOverview, error) {
+	type counts struct {
+		Total    int `json:"total"`
+		Warnings int `json:"warnings"`
+		Errors   int `json:"errors"`
+	}
+	var o struct {
+		HTTP struct {
+			Routers     counts `json:"routers"`
+			Services    counts `json:"services"`
+			Middlewares counts `json:"middlewares"`
+		} `json:"http"`
+	}
+	if err := c.get(ctx, "/api/overview", &o); err != nil {
+		return domain.TraefikOverview{}, err
+	}
+	return domain.TraefikOverview{
+		Routers:     domain.TraefikCounts(o.HTTP.Routers),
+		Services:    domain.TraefikCounts(o.HTTP.Services),
+		Middlewares: domain.TraefikCounts(o.HTTP.Middlewares),
+	}, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(c.URL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("traefik api %s: status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,53 @@
+package traefik
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/http/routers":
+			w.Write([]byte(`[
+				{"name": "whoami@docker", "provider": "docker", "rule": "Host(` + "`who.example.com`" + `)", "service": "whoami", "entryPoints": ["websecure"], "status": "enabled", "tls": {}},
+				{"name": "broken@file", "provider": "file", "rule": "Host(` + "`b.example.com`" + `) && PathPrefix(` + "`/x`" + `)", "status": "disabled", "error": ["the service \"nope@file\" does not exist"]}
+			]`))
+		case "/api/overview":
+			w.Write([]byte(`{"http": {"routers": {"total": 2, "warnings": 0, "errors": 1}, "services": {"total": 1}, "middlewares": {"total": 3, "warnings": 1}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := &Client{URL: srv.URL + "/"}
+
+	routes, err := c.Routers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("got %d routes", len(routes))
+	}
+	who, broken := routes[0], routes[1]
+	if who.Router != "whoami" || who.Provider != "docker" || !who.TLS || who.URL() != "https://who.example.com" || who.Status != "enabled" {
+		t.Errorf("unexpected route: %+v", who)
+	}
+	if broken.Router != "broken" || broken.Path != "/x" || broken.Status != "disabled" || len(broken.Errors) != 1 {
+		t.Errorf("unexpected route: %+v", broken)
+	}
+
+	o, err := c.Overview(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Routers.Total != 2 || o.Routers.Errors != 1 || o.Middlewares.Warnings != 1 {
+		t.Errorf("unexpected overview: %+v", o)
+	}
+
+	if _, err := (&Client{URL: srv.URL + "/missing"}).Overview(context.Background()); err == nil {
+		t.Error("expected an error for a 404")
+	}
+}
@@ -26,7 +26,23 @@ func (u *UiAdapter) SetData(containers []domain.Container) {
 				Image:      c.Image,
 				CPUPercent: c.CPUPercent,
 				Mem:        c.MemoryMB,
+				MemLimit:   c.MemoryLimitMB,
+				NetRx:      c.NetRxBytes,
+				NetTx:      c.NetTxBytes,
+				BlockRead:  c.BlockReadBytes,
+				BlockWrite: c.BlockWriteBytes,
+				PIDs:       c.PIDs,
+				Ports:      c.Ports,
+				URL:        c.URL,
+				Routes:     c.Routes,
+				Routing:    c.Routing,
+				Networks:   c.Networks,
 				Status:     c.Status,
+				StatusText: c.StatusText,
+				Labels:     c.Labels,
+				Health:     c.Health,
+				Lifecycle:  c.Lifecycle,
+				Alerts:     c.Alerts,
 			},
 			Specific: c.Details,
 		})
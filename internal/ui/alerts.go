@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+const maxAlertPanelLines = 5
+
+func (m *UiModel) firingAlerts() []domain.Alert {
+	var out []domain.Alert
+	for _, c := range m.items {
+		out = append(out, c.Alerts...)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Since.Before(out[j].Since) })
+	return out
+}
+
+func (m *UiModel) renderAlertPanel() string {
+	alerts := m.firingAlerts()
+	if len(alerts) == 0 {
+		return ""
+	}
+	lines := []string{lipgloss.NewStyle().Foreground(lipgloss.Color(colorDanger)).Bold(true).Render(fmt.Sprintf("⚠ %d firing", len(alerts)))}
+	for i, a := range alerts {
+		if i == maxAlertPanelLines {
+			lines = append(lines, labelStyle.Render(fmt.Sprintf("+%d more", len(alerts)-i)))
+			break
+		}
+		lines = append(lines, alertLine(a))
+	}
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	return alertPanelStyle.Width(width - 2).Render(joinLines(lines))
+}
+
+func alertLine(a domain.Alert) string {
+	color := colorWarning
+	if a.Severity == "critical" {
+		color = colorDanger
+	}
+	sev := a.Severity
+	if sev == "" {
+		sev = "alert"
+	}
+	line := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render("["+sev+"]") + " " +
+		valueStyle.Render(a.Rule) + labelStyle.Render(" on ") + valueStyle.Render(a.Container)
+	if a.Value != "" {
+		line += labelStyle.Render(" " + a.Value)
+	}
+	return line + labelStyle.Render(" for "+FormatDuration(time.Since(a.Since)))
+}
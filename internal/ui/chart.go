@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var chartBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// bucketSeries spreads points over width columns between from and to and
+// keeps the peak of each column. Columns without data are NaN.
+func bucketSeries(times []time.Time, values []float64, from, to time.Time, width int) []float64 {
+	cols := make([]float64, width)
+	for i := range cols {
+		cols[i] = math.NaN()
+	}
+	span := to.Sub(from)
+	if width <= 0 || span <= 0 {
+		return cols
+	}
+	for i, t := range times {
+		if t.Before(from) || t.After(to) {
+			continue
+		}
+		c := min(int(float64(t.Sub(from))/float64(span)*float64(width)), width-1)
+		if math.IsNaN(cols[c]) || values[i] > cols[c] {
+			cols[c] = values[i]
+		}
+	}
+	return cols
+}
+
+// renderChart draws columns as a bar chart height rows tall using eighth
+// blocks, with the peak value on the y axis and the range ends below.
+func renderChart(title string, cols []float64, height int, color string, format func(float64) string, from, to time.Time) string {
+	peak := 0.0
+	for _, v := range cols {
+		if !math.IsNaN(v) {
+			peak = max(peak, v)
+		}
+	}
+	top := format(peak)
+	axisW := max(lipgloss.Width(top), lipgloss.Width(format(0)))
+	bar := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+
+	lines := []string{sectionStyle.Render(title)}
+	for row := height - 1; row >= 0; row-- {
+		var b strings.Builder
+		for _, v := range cols {
+			if math.IsNaN(v) || peak == 0 {
+				b.WriteRune(' ')
+				continue
+			}
+			eighths := int(math.Round(v / peak * float64(height*8)))
+			fill := min(max(eighths-row*8, 0), 8)
+			b.WriteRune(chartBlocks[fill])
+		}
+		label := ""
+		switch row {
+		case height - 1:
+			label = top
+		case 0:
+			label = format(0)
+		}
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("%*s ┤", axisW, label))+bar.Render(b.String()))
+	}
+	start, end := from.Local().Format("Jan 2 15:04"), to.Local().Format("Jan 2 15:04")
+	gap := max(len(cols)-len(start)-len(end), 1)
+	lines = append(lines, labelStyle.Render(strings.Repeat(" ", axisW+2)+start+strings.Repeat(" ", gap)+end))
+	return joinLines(lines)
+}
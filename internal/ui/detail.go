@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+func (m *UiModel) renderDetail(c fetcher.ContainerInfo) string {
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	inner := width - 6
+	lines := []string{
+		titleLine("\U0001F50E", baseName(c), inner+4, lipgloss.Color(colorPrimary)),
+		statusLine(c),
+		"",
+		labelStyle.Render("Type:    ") + valueStyle.Render(string(c.Type)),
+		labelStyle.Render("Status:  ") + valueStyle.Render(c.StatusText),
+		labelStyle.Render("Image:   ") + valueStyle.Render(TruncateString(c.Image, inner-9)),
+		labelStyle.Render("ID:      ") + valueStyle.Render(c.ID),
+		labelStyle.Render("CPU:     ") + statsStyle.Render(fmt.Sprintf("%.1f%%", c.CPUPercent)),
+		labelStyle.Render("Memory:  ") + statsStyle.Render(fmt.Sprintf("%d MB", c.Mem)),
+	}
+	if c.URL != "" {
+		lines = append(lines, labelStyle.Render("URL:     ")+hyperlink(c.URL, valueStyle.Render(TruncateString(c.URL, inner-9))))
+	}
+	if len(c.Alerts) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Alerts"))
+		for _, a := range c.Alerts {
+			lines = append(lines, alertLine(a))
+		}
+	}
+	lines = append(lines, detailCompose(c, inner)...)
+	lines = append(lines, detailLifecycle(c)...)
+	lines = append(lines, detailHealth(c, inner)...)
+	lines = append(lines, detailRoutes(c, inner)...)
+	lines = append(lines, detailNetworks(c, inner)...)
+	if d := c.Specific; d != nil {
+		fields := d.DetailFields()
+		if len(fields) > 0 {
+			lines = append(lines, "", sectionStyle.Render("Details"))
+			for _, f := range fields {
+				lines = append(lines, fieldLine(f, inner))
+			}
+		}
+	}
+	return detailStyle.Width(width - 2).Render(joinLines(lines))
+}
+
+func detailCompose(c fetcher.ContainerInfo, inner int) []string {
+	cp := composeOf(c)
+	if cp.Project == "" {
+		return nil
+	}
+	lines := []string{"", sectionStyle.Render("Compose")}
+	lines = append(lines, labelStyle.Render("Project:  ")+valueStyle.Render(cp.Project))
+	if cp.Service != "" {
+		service := cp.Service
+		if cp.Number > 0 {
+			service += fmt.Sprintf(" #%d", cp.Number)
+		}
+		lines = append(lines, labelStyle.Render("Service:  ")+valueStyle.Render(service))
+	}
+	if cp.WorkingDir != "" {
+		lines = append(lines, labelStyle.Render("Dir:      ")+valueStyle.Render(TruncateString(cp.WorkingDir, max(inner-10, 4))))
+	}
+	for i, f := range cp.ConfigFiles {
+		label := "Config:   "
+		if i > 0 {
+			label = "          "
+		}
+		lines = append(lines, labelStyle.Render(label)+valueStyle.Render(TruncateString(f, max(inner-10, 4))))
+	}
+	return lines
+}
+
+func detailLifecycle(c fetcher.ContainerInfo) []string {
+	l := c.Lifecycle
+	lines := []string{"", sectionStyle.Render("Lifecycle")}
+	if !l.StartedAt.IsZero() {
+		lines = append(lines, labelStyle.Render("Started:  ")+valueStyle.Render(l.StartedAt.Local().Format(time.DateTime)))
+	}
+	if !l.FinishedAt.IsZero() {
+		lines = append(lines, labelStyle.Render("Finished: ")+valueStyle.Render(l.FinishedAt.Local().Format(time.DateTime)))
+	}
+	if reason := l.ExitReason(); reason != "" {
+		lines = append(lines, labelStyle.Render("Last exit: ")+valueStyle.Render(reason))
+	}
+	restarts := fmt.Sprintf("%d total, %d recent", l.RestartCount, l.RecentRestarts)
+	if l.RestartStorm {
+		restarts += " " + lipgloss.NewStyle().Foreground(lipgloss.Color(colorDanger)).Bold(true).Render("(restart storm)")
+	}
+	return append(lines, labelStyle.Render("Restarts: ")+valueStyle.Render(restarts))
+}
+
+func detailHealth(c fetcher.ContainerInfo, width int) []string {
+	h := c.Health
+	if h == nil {
+		return nil
+	}
+	lines := []string{"", sectionStyle.Render("Healthcheck")}
+	lines = append(lines, labelStyle.Render("Failing streak: ")+valueStyle.Render(fmt.Sprintf("%d", h.FailingStreak)))
+	if len(h.Log) == 0 {
+		return append(lines, emptyStyle.Render("no probes recorded yet"))
+	}
+	for i := len(h.Log) - 1; i >= 0; i-- {
+		p := h.Log[i]
+		color := colorSuccess
+		if p.ExitCode != 0 {
+			color = colorDanger
+		}
+		head := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(fmt.Sprintf("exit %d", p.ExitCode))
+		when := labelStyle.Render(p.End.Local().Format("15:04:05"))
+		out := strings.Join(strings.Fields(p.Output), " ")
+		if out == "" {
+			out = "(no output)"
+		}
+		lines = append(lines, when+" "+head+" "+valueStyle.Render(TruncateString(out, max(width-18, 4))))
+	}
+	return lines
+}
@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+// DiskSource lists images and volumes and what they take on disk;
+// fetcher.Fetcher implements it.
+type DiskSource interface {
+	Disk(ctx context.Context) (domain.DiskUsage, error)
+}
+
+var diskTabs = []string{"Images", "Volumes", "Usage"}
+
+// /system/df walks every volume, so it is asked for less often than the
+// other screens refresh.
+const diskRefresh = 30 * time.Second
+
+type diskState struct {
+	open    bool
+	tab     int
+	usage   *domain.DiskUsage
+	err     error
+	loading bool
+	fetched time.Time
+	scroll  int
+	// confirm is the prune waiting for a yes.
+	confirm docker.PruneTarget
+}
+
+type diskMsg struct {
+	usage domain.DiskUsage
+	err   error
+}
+
+type pruneResultMsg struct {
+	target docker.PruneTarget
+	report docker.PruneReport
+	err    error
+}
+
+func (r pruneResultMsg) String() string {
+	noun := strings.TrimSuffix(string(r.target), "s")
+	if r.err != nil && r.report.Deleted > 0 {
+		return fmt.Sprintf("pruned %s, then failed: %v", plural(r.report.Deleted, noun), r.err)
+	}
+	if r.err != nil {
+		return fmt.Sprintf("prune %s failed: %v", r.target, r.err)
+	}
+	return fmt.Sprintf("pruned %s, %s reclaimed", plural(r.report.Deleted, noun), FormatBytes(r.report.SpaceReclaimed))
+}
+
+// SetDisk enables the images, volumes and disk usage screen.
+func (m *UiModel) SetDisk(d DiskSource) { m.disk = d }
+
+// SetPrune enables pruning on the disk screen.
+func (m *UiModel) SetPrune(p docker.PruneClient) { m.prune = p }
+
+// updateDisk handles the keys of the disk screen; ok is false for keys it
+// leaves to the main handler.
+func (m *UiModel) updateDisk(msg tea.KeyMsg) (cmd tea.Cmd, ok bool) {
+	km := m.keys
+	_, page := m.diskLines()
+	switch {
+	case key.Matches(msg, km.Back), key.Matches(msg, km.Disk):
+		m.dsk.open = false
+	case key.Matches(msg, km.ColumnNext):
+		m.dsk.tab, m.dsk.scroll = (m.dsk.tab+1)%len(diskTabs), 0
+	case key.Matches(msg, km.ColumnPrev):
+		m.dsk.tab, m.dsk.scroll = (m.dsk.tab+len(diskTabs)-1)%len(diskTabs), 0
+	case key.Matches(msg, km.Down):
+		m.scrollDisk(1)
+	case key.Matches(msg, km.Up):
+		m.scrollDisk(-1)
+	case key.Matches(msg, km.NextPage):
+		m.scrollDisk(page)
+	case key.Matches(msg, km.PrevPage):
+		m.scrollDisk(-page)
+	case key.Matches(msg, km.Prune):
+		m.askPrune()
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+func (m *UiModel) openDisk() tea.Cmd {
+	if m.disk == nil {
+		return nil
+	}
+	m.dsk.open, m.dsk.scroll = true, 0
+	return m.queryDisk()
+}
+
+func (m *UiModel) queryDisk() tea.Cmd {
+	m.dsk.loading = true
+	src := m.disk
+	return func() tea.Msg {
+		usage, err := src.Disk(context.Background())
+		return diskMsg{usage: usage, err: err}
+	}
+}
+
+func (m *UiModel) setDisk(msg diskMsg) {
+	m.dsk.loading, m.dsk.fetched, m.dsk.err = false, time.Now(), msg.err
+	if msg.err == nil {
+		m.dsk.usage = &msg.usage
+	}
+}
+
+func (m *UiModel) scrollDisk(delta int) {
+	lines, page := m.diskLines()
+	m.dsk.scroll = min(max(m.dsk.scroll+delta, 0), max(len(lines)-page, 0))
+}
+
+// pruneTarget is what the prune key removes on the current tab and how much
+// of it there is.
+func (m *UiModel) pruneTarget() (target docker.PruneTarget, what string, n int) {
+	if m.dsk.usage == nil {
+		return "", "", 0
+	}
+	switch m.dsk.tab {
+	case 0:
+		n, size := m.dsk.usage.DanglingImages()
+		return docker.PruneImages, fmt.Sprintf("%s (%s)", plural(n, "dangling image"), FormatBytes(size)), n
+	case 1:
+		n, size := m.dsk.usage.UnusedVolumes()
+		return docker.PruneVolumes, fmt.Sprintf("%s (%s)", plural(n, "unused anonymous volume"), FormatBytes(size)), n
+	}
+	return "", "", 0
+}
+
+func (m *UiModel) askPrune() {
+	if m.prune == nil {
+		return
+	}
+	target, what, n := m.pruneTarget()
+	switch {
+	case target == "":
+		m.notice = "prune works on the images and volumes tabs"
+	case n == 0:
+		m.notice = "nothing to prune: " + what
+	default:
+		m.dsk.confirm = target
+	}
+}
+
+// updateConfirm answers the prune question: y prunes, any other key
+// cancels.
+func (m *UiModel) updateConfirm(msg tea.KeyMsg) tea.Cmd {
+	target := m.dsk.confirm
+	m.dsk.confirm = ""
+	if msg.String() != "y" {
+		m.notice = "prune cancelled"
+		return nil
+	}
+	m.notice = fmt.Sprintf("pruning %s...", target)
+	client := m.prune
+	if target == docker.PruneVolumes && m.dsk.usage != nil {
+		volumes := m.dsk.usage.PrunableVolumes()
+		return func() tea.Msg { return removeVolumes(client, volumes) }
+	}
+	return func() tea.Msg {
+		report, err := client.Prune(context.Background(), target)
+		return pruneResultMsg{target: target, report: report, err: err}
+	}
+}
+
+// removeVolumes deletes exactly the volumes the prompt counted, rather than
+// using the daemon's volume prune, which takes named volumes along before
+// API 1.42. It goes on past failures and reports the first.
+func removeVolumes(client docker.PruneClient, volumes []domain.Volume) pruneResultMsg {
+	msg := pruneResultMsg{target: docker.PruneVolumes}
+	for _, v := range volumes {
+		if err := client.RemoveVolume(context.Background(), v.Name); err != nil {
+			if msg.err == nil {
+				msg.err = err
+			}
+			continue
+		}
+		msg.report.Deleted++
+		msg.report.SpaceReclaimed += uint64(max(v.Size, 0))
+	}
+	return msg
+}
+
+func (m *UiModel) confirmText() string {
+	_, what, _ := m.pruneTarget()
+	return fmt.Sprintf("Prune %s? y/n", what)
+}
+
+// usedBy names the containers using an image or volume.
+func usedBy(containers []string) string {
+	if len(containers) == 0 {
+		return "unused"
+	}
+	return strings.Join(containers, ", ")
+}
+
+func sizeText(size int64) string {
+	if size < 0 {
+		return "?"
+	}
+	return FormatBytes(uint64(size))
+}
+
+// diskLines is the body of the current tab and how many lines of it fit.
+func (m *UiModel) diskLines() ([]string, int) {
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	height := m.termSize.Height
+	if height <= 0 {
+		height = 30
+	}
+	inner := width - 6
+	// title, tabs, blank line, borders and footer
+	page := max(height-6, 1)
+	d := m.dsk.usage
+	switch {
+	case m.dsk.err != nil:
+		return []string{dangerText(TruncateString(m.dsk.err.Error(), inner))}, page
+	case d == nil:
+		return []string{emptyStyle.Render("Loading images and volumes...")}, page
+	}
+	var lines []string
+	if d.Err != "" {
+		lines = append(lines, dangerText(TruncateString("sizes unavailable: "+d.Err, inner)))
+	}
+	sizeWidth := 9
+	switch m.dsk.tab {
+	case 0:
+		n, size := d.DanglingImages()
+		lines = append(lines, valueStyle.Render(fmt.Sprintf("%s, %s (%s)", plural(len(d.Images), "image"), plural(n, "dangling image"), FormatBytes(size))), "")
+		tagWidth, ageWidth := max(inner*2/5, 24), 8
+		lines = append(lines, labelStyle.Render(fitCell("IMAGE", tagWidth, false)+" "+fitCell("SIZE", sizeWidth, true)+" "+fitCell("AGE", ageWidth, true)+"  USED BY"))
+		for _, img := range d.Images {
+			tag := valueStyle.Render(fitCell(strings.Join(img.Tags, ", "), tagWidth, false))
+			if img.Dangling() {
+				tag = lipgloss.NewStyle().Foreground(lipgloss.Color(colorWarning)).Render(fitCell("<none> "+shortID(strings.TrimPrefix(img.ID, "sha256:")), tagWidth, false))
+			}
+			lines = append(lines, tag+" "+statsStyle.Render(fitCell(FormatBytes(img.Size), sizeWidth, true))+" "+
+				labelStyle.Render(fitCell(FormatDuration(time.Since(img.Created)), ageWidth, true))+"  "+
+				valueStyle.Render(fitCell(usedBy(img.Containers), max(inner-tagWidth-sizeWidth-ageWidth-4, 8), false)))
+		}
+	case 1:
+		n, size := d.UnusedVolumes()
+		lines = append(lines, valueStyle.Render(fmt.Sprintf("%s, %s (%s)", plural(len(d.Volumes), "volume"), plural(n, "unused anonymous volume"), FormatBytes(size))), "")
+		nameWidth, usedWidth := max(inner/4, 20), max(inner/5, 12)
+		lines = append(lines, labelStyle.Render(fitCell("VOLUME", nameWidth, false)+" "+fitCell("SIZE", sizeWidth, true)+"  "+fitCell("USED BY", usedWidth, false)+" MOUNTPOINT"))
+		for _, v := range d.Volumes {
+			lines = append(lines, valueStyle.Render(fitCell(v.Name, nameWidth, false))+" "+statsStyle.Render(fitCell(sizeText(v.Size), sizeWidth, true))+"  "+
+				valueStyle.Render(fitCell(usedBy(v.Containers), usedWidth, false))+" "+
+				labelStyle.Render(fitCell(v.Mountpoint, max(inner-nameWidth-sizeWidth-usedWidth-4, 8), false)))
+		}
+	case 2:
+		if len(d.Totals) == 0 {
+			return append(lines, emptyStyle.Render("no disk usage reported")), page
+		}
+		var total uint64
+		for _, t := range d.Totals {
+			total += t.Size
+		}
+		lines = append(lines, valueStyle.Render(fmt.Sprintf("%s of %s reclaimable", FormatBytes(d.Reclaimable()), FormatBytes(total))), "")
+		lines = append(lines, labelStyle.Render(fitCell("TYPE", 12, false)+" "+fitCell("TOTAL", 6, true)+" "+fitCell("ACTIVE", 6, true)+" "+fitCell("SIZE", sizeWidth, true)+"  RECLAIMABLE"))
+		for _, t := range d.Totals {
+			reclaim := FormatBytes(t.Reclaimable)
+			if t.Size > 0 {
+				reclaim += fmt.Sprintf(" (%.0f%%)", float64(t.Reclaimable)/float64(t.Size)*100)
+			}
+			lines = append(lines, valueStyle.Render(fitCell(t.Kind, 12, false))+" "+valueStyle.Render(fitCell(fmt.Sprint(t.Count), 6, true))+" "+
+				valueStyle.Render(fitCell(fmt.Sprint(t.Active), 6, true))+" "+statsStyle.Render(fitCell(FormatBytes(t.Size), sizeWidth, true))+"  "+
+				lipgloss.NewStyle().Foreground(lipgloss.Color(colorWarning)).Render(reclaim))
+		}
+	}
+	return lines, page
+}
+
+func (m *UiModel) renderDisk() string {
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	lines, page := m.diskLines()
+	m.dsk.scroll = min(m.dsk.scroll, max(len(lines)-page, 0))
+	var tabs []string
+	for i, t := range diskTabs {
+		style := labelStyle.Padding(0, 1)
+		if i == m.dsk.tab {
+			style = style.Foreground(lipgloss.Color(colorLogo)).Underline(true)
+		}
+		tabs = append(tabs, style.Render(t))
+	}
+	if end := min(m.dsk.scroll+page, len(lines)); len(lines) > page {
+		lines = lines[m.dsk.scroll:end]
+	}
+	head := []string{
+		titleLine("\U0001F4BE", "Disk", width-2, lipgloss.Color(colorPrimary)),
+		lipgloss.JoinHorizontal(lipgloss.Top, tabs...),
+		"",
+	}
+	return detailStyle.Width(width - 2).Render(joinLines(append(head, lines...)))
+}
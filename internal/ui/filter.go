@@ -0,0 +1,85 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/filter"
+)
+
+type filterPrompt struct {
+	open     bool
+	text     string
+	previous *filter.Filter
+	err      error
+}
+
+// SetHost tells host: filter terms which daemon the containers come from.
+func (m *UiModel) SetHost(host string) {
+	m.host = host
+	if m.filter != nil {
+		m.filter.Host = host
+	}
+}
+
+// SetFilter sets the active filter, e.g. from --filter.
+func (m *UiModel) SetFilter(f *filter.Filter) {
+	if f != nil {
+		f.Host = m.host
+	}
+	m.filter = f
+	m.rebuild()
+}
+
+func (m *UiModel) openPrompt() {
+	m.prompt = filterPrompt{open: true, text: m.filter.String(), previous: m.filter}
+}
+
+// updatePrompt edits the query and applies it on every keystroke that
+// leaves it valid, so the grid narrows down while typing.
+func (m *UiModel) updatePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return tea.Quit
+	case tea.KeyEsc:
+		m.prompt.open = false
+		m.SetFilter(m.prompt.previous)
+		return nil
+	case tea.KeyEnter:
+		if m.prompt.err == nil {
+			m.prompt.open = false
+		}
+		return nil
+	case tea.KeyBackspace:
+		if r := []rune(m.prompt.text); len(r) > 0 {
+			m.prompt.text = string(r[:len(r)-1])
+		}
+	case tea.KeyCtrlU:
+		m.prompt.text = ""
+	case tea.KeySpace:
+		m.prompt.text += " "
+	case tea.KeyRunes:
+		m.prompt.text += string(msg.Runes)
+	default:
+		return nil
+	}
+	f, err := filter.Parse(m.prompt.text)
+	m.prompt.err = err
+	if err == nil {
+		if f.Empty() {
+			f = nil
+		}
+		m.SetFilter(f)
+	}
+	return nil
+}
+
+func (m *UiModel) renderPrompt() string {
+	line := lipgloss.NewStyle().Foreground(lipgloss.Color(colorLogo)).Bold(true).Render("/") +
+		valueStyle.Render(m.prompt.text) + lipgloss.NewStyle().Reverse(true).Render(" ")
+	if m.prompt.err != nil {
+		line += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color(colorDanger)).Render(m.prompt.err.Error())
+	} else {
+		line += "  " + labelStyle.Render("enter apply  esc cancel")
+	}
+	return line
+}
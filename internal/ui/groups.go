@@ -0,0 +1,311 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+// gridEntry is one selectable slot in the grid: a container card, or with
+// item -1 the header of a compose project.
+type gridEntry struct {
+	item    int
+	project string
+}
+
+func (e gridEntry) header() bool { return e.item < 0 }
+
+func (m *UiModel) entryKey(e gridEntry) string {
+	if e.header() {
+		return "project:" + e.project
+	}
+	return m.items[e.item].ID
+}
+
+func composeOf(c fetcher.ContainerInfo) domain.Compose { return domain.ComposeFromLabels(c.Labels) }
+
+type projectGroup struct {
+	name  string
+	items []int
+}
+
+// projectGroups orders compose projects by name with standalone containers
+// last, keeping the incoming order inside each group.
+func projectGroups(items []fetcher.ContainerInfo, indices []int) []projectGroup {
+	index := map[string]int{}
+	var groups []projectGroup
+	for _, i := range indices {
+		p := composeOf(items[i]).Project
+		g, ok := index[p]
+		if !ok {
+			g = len(groups)
+			index[p] = g
+			groups = append(groups, projectGroup{name: p})
+		}
+		groups[g].items = append(groups[g].items, i)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].name == "") != (groups[j].name == "") {
+			return groups[j].name == ""
+		}
+		return groups[i].name < groups[j].name
+	})
+	return groups
+}
+
+// rebuild recomputes the grid entries after the items, filter, grouping or
+// collapsed projects change, and puts the cursor back on the selected entry.
+// When the selected container disappears into a collapsed project, its
+// header takes over the selection.
+func (m *UiModel) rebuild() {
+	m.visible = m.visible[:0]
+	for i, c := range m.items {
+		if m.filter.Match(c.Domain()) {
+			m.visible = append(m.visible, i)
+		}
+	}
+	m.sortVisible()
+	m.entries = m.entries[:0]
+	groups := projectGroups(m.items, m.visible)
+	if !m.grouped || len(groups) == 0 || (len(groups) == 1 && groups[0].name == "") {
+		for _, i := range m.visible {
+			m.entries = append(m.entries, gridEntry{item: i})
+		}
+	} else {
+		for _, g := range groups {
+			m.entries = append(m.entries, gridEntry{item: -1, project: g.name})
+			if m.collapsed[g.name] {
+				continue
+			}
+			for _, i := range g.items {
+				m.entries = append(m.entries, gridEntry{item: i, project: g.name})
+			}
+		}
+	}
+
+	fallback := ""
+	for _, c := range m.items {
+		if c.ID == m.selected {
+			fallback = "project:" + composeOf(c).Project
+		}
+	}
+	m.cursor = min(m.cursor, max(len(m.entries)-1, 0))
+	for _, key := range []string{m.selected, fallback} {
+		if i := m.findEntry(key); i >= 0 {
+			m.cursor = i
+			break
+		}
+	}
+	m.syncSelection()
+}
+
+func (m *UiModel) findEntry(key string) int {
+	if key == "" {
+		return -1
+	}
+	for i, e := range m.entries {
+		if m.entryKey(e) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *UiModel) SetGrouped(grouped bool) {
+	m.grouped = grouped
+	m.rebuild()
+}
+
+func (m *UiModel) hasProjects() bool {
+	for _, c := range m.items {
+		if composeOf(c).Project != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// currentProject is the project whose header is under the cursor.
+func (m *UiModel) currentProject() (string, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.entries) || !m.entries[m.cursor].header() {
+		return "", false
+	}
+	return m.entries[m.cursor].project, true
+}
+
+func (m *UiModel) toggleCollapsed(project string) {
+	if m.collapsed == nil {
+		m.collapsed = map[string]bool{}
+	}
+	m.collapsed[project] = !m.collapsed[project]
+	m.rebuild()
+}
+
+func projectLabel(name string) string {
+	if name == "" {
+		return "standalone"
+	}
+	return name
+}
+
+// actProject applies an action to every shown container of a project: restart
+// restarts all of them, stop only the running ones and start only the
+// stopped ones.
+func (m *UiModel) actProject(project string, action docker.Action) tea.Cmd {
+	if m.actions == nil {
+		return nil
+	}
+	type target struct{ id, name string }
+	var targets []target
+	for _, c := range m.projectItems(project) {
+		running := c.Status == "running"
+		if (action == docker.ActionStop && !running) || (action == docker.ActionStart && running) {
+			continue
+		}
+		targets = append(targets, target{c.ID, baseName(c)})
+	}
+	label := "project " + projectLabel(project)
+	if len(targets) == 0 {
+		m.notice = fmt.Sprintf("%s: nothing to %s", label, action)
+		return nil
+	}
+	m.notice = fmt.Sprintf("%s %s (%d containers)...", action, label, len(targets))
+	client := m.actions
+	return func() tea.Msg {
+		var errs []error
+		for _, t := range targets {
+			if err := client.ContainerAction(context.Background(), t.id, action); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", t.name, err))
+			}
+		}
+		return actionResultMsg{name: label, action: action, err: errors.Join(errs...)}
+	}
+}
+
+// projectItems are the containers of a project that pass the filter; header
+// totals and project actions only cover what is on screen.
+func (m *UiModel) projectItems(project string) []fetcher.ContainerInfo {
+	var out []fetcher.ContainerInfo
+	for _, i := range m.visible {
+		if composeOf(m.items[i]).Project == project {
+			out = append(out, m.items[i])
+		}
+	}
+	return out
+}
+
+func (m *UiModel) projectRunning(project string) bool {
+	for _, c := range m.projectItems(project) {
+		if c.Status == "running" {
+			return true
+		}
+	}
+	return false
+}
+
+// totals is what a project header shows about its containers.
+type totals struct {
+	running, total int
+	cpu            float64
+	mem            uint64
+	// arrow shows whether the project is collapsed and countColor whether
+	// all of it is running.
+	arrow, countColor string
+}
+
+func (m *UiModel) projectTotals(project string) totals {
+	t := totals{arrow: "▾", countColor: colorSuccess}
+	for _, c := range m.projectItems(project) {
+		t.total++
+		if c.Status == "running" {
+			t.running++
+		}
+		t.cpu += c.CPUPercent
+		t.mem += c.Mem
+	}
+	if m.collapsed[project] {
+		t.arrow = "▸"
+	}
+	if t.running < t.total {
+		t.countColor = colorWarning
+	}
+	return t
+}
+
+func (m *UiModel) renderProjectHeader(project string, width int, selected bool) string {
+	t := m.projectTotals(project)
+	line := lipgloss.JoinHorizontal(lipgloss.Top,
+		titleStyle.Foreground(lipgloss.Color(colorLogo)).Render(t.arrow+" "+projectLabel(project)),
+		lipgloss.NewStyle().Foreground(lipgloss.Color(t.countColor)).Bold(true).Render(fmt.Sprintf("%d/%d running", t.running, t.total)),
+		statsStyle.Render(fmt.Sprintf("   CPU: %.1f%%  MEM: %dMB", t.cpu, t.mem)),
+	)
+	style := lipgloss.NewStyle().Width(width).Border(lipgloss.NormalBorder(), false, false, true, false).BorderForeground(lipgloss.Color(colorGenericDark))
+	if selected {
+		return selectedStyle.Render(style.Width(width - 1).Render(line))
+	}
+	return style.Render(line)
+}
+
+// groupedPageStarts pages a grouped grid by the lines it renders: headers
+// take a row of their own and end the card row before them, so a page of
+// small projects holds fewer entries than cols*rows.
+func (m *UiModel) groupedPageStarts() []int {
+	cols, rows, _ := m.layoutSpec()
+	const headerH, cardRowH = 2, 12
+	budget := rows * cardRowH
+	starts := []int{0}
+	used, inRow := 0, 0
+	for i, e := range m.entries {
+		cost := 0
+		switch {
+		case e.header():
+			cost, inRow = headerH, 0
+		case inRow == 0:
+			cost = cardRowH
+		}
+		if used+cost > budget && i > starts[len(starts)-1] {
+			starts = append(starts, i)
+			used = 0
+			if !e.header() {
+				inRow, cost = 0, cardRowH
+			}
+		}
+		used += cost
+		if !e.header() {
+			inRow = (inRow + 1) % cols
+		}
+	}
+	return starts
+}
+
+// renderGroupedGrid lays out headers as full-width rows and the cards of a
+// project in rows of cols beneath them.
+func (m *UiModel) renderGroupedGrid(visible []gridEntry, start, cols, boxWidth, width int) string {
+	var rows, row []string
+	flush := func() {
+		if len(row) > 0 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row = nil
+		}
+	}
+	for i, e := range visible {
+		if e.header() {
+			flush()
+			rows = append(rows, m.renderProjectHeader(e.project, width-2, start+i == m.cursor))
+			continue
+		}
+		row = append(row, m.renderCard(m.items[e.item], boxWidth, start+i == m.cursor))
+		if len(row) == cols {
+			flush()
+		}
+	}
+	flush()
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpKey is a binding as listed in the help overlay; desc, when set,
+// replaces the binding's own description for that screen.
+type helpKey struct {
+	b    key.Binding
+	desc string
+}
+
+type helpSection struct {
+	title string
+	keys  []helpKey
+}
+
+// helpSections groups the bindings by the screen they work on, leaving out
+// features the UI was started without.
+func (m *UiModel) helpSections() []helpSection {
+	km := m.keys
+	grid := []helpKey{
+		{b: km.Down}, {b: km.Up}, {b: km.NextPage}, {b: km.PrevPage},
+		{b: km.Details}, {b: km.Back}, {b: km.Filter}, {b: km.Group}, {b: km.Table},
+		{b: km.SortNext}, {b: km.SortPrev}, {b: km.SortInvert}, {b: km.Open}, {b: km.Topology},
+	}
+	if m.disk != nil {
+		grid = append(grid, helpKey{b: km.Disk})
+	}
+	detail := []helpKey{{b: km.Back, desc: "back"}, {b: km.Down}, {b: km.Up}, {b: km.Open}}
+	if m.history != nil {
+		grid = append(grid, helpKey{b: km.History})
+		detail = append(detail, helpKey{b: km.History})
+	}
+	if m.actions != nil {
+		actions := []helpKey{{b: km.Restart}, {b: km.StartStop}, {b: km.Pause}}
+		grid = append(grid, actions...)
+		detail = append(detail, actions...)
+	}
+	sections := []helpSection{
+		{"Global", []helpKey{{b: km.Quit}, {b: km.Help}, {b: key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit"))}}},
+		{"Grid", grid},
+		{"Table", []helpKey{{b: km.ColumnNext}, {b: km.ColumnPrev}, {b: km.Wider}, {b: km.Narrower}, {b: km.NextPage, desc: "next screen"}, {b: km.PrevPage, desc: "previous screen"}}},
+		{"Detail", detail},
+	}
+	if m.history != nil {
+		sections = append(sections, helpSection{"History", []helpKey{
+			{b: km.RangePrev}, {b: km.RangeNext}, {b: km.Down}, {b: km.Up}, {b: km.Back, desc: "back"}, {b: km.History, desc: "back"},
+		}})
+	}
+	sections = append(sections, helpSection{"Ports & networks", []helpKey{
+		{b: km.Down, desc: "scroll down"}, {b: km.Up, desc: "scroll up"}, {b: km.NextPage, desc: "page down"}, {b: km.PrevPage, desc: "page up"},
+		{b: km.Back, desc: "back"}, {b: km.Topology, desc: "back"},
+	}})
+	if m.disk != nil {
+		keys := []helpKey{
+			{b: km.ColumnNext, desc: "next tab"}, {b: km.ColumnPrev, desc: "previous tab"},
+			{b: km.Down, desc: "scroll down"}, {b: km.Up, desc: "scroll up"}, {b: km.NextPage, desc: "page down"}, {b: km.PrevPage, desc: "page up"},
+		}
+		if m.prune != nil {
+			keys = append(keys, helpKey{b: km.Prune, desc: "prune, asks first"})
+		}
+		sections = append(sections, helpSection{"Disk", append(keys, helpKey{b: km.Back, desc: "back"}, helpKey{b: km.Disk, desc: "back"})})
+	}
+	return append(sections, helpSection{"Filter prompt", []helpKey{
+		{b: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "apply"))},
+		{b: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel"))},
+		{b: key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "clear"))},
+	}})
+}
+
+func (m *UiModel) renderHelp() string {
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	inner := width - 6
+	var blocks []string
+	for _, s := range m.helpSections() {
+		var keys []helpKey
+		keyWidth := 0
+		for _, k := range s.keys {
+			if k.b.Enabled() {
+				keys = append(keys, k)
+				keyWidth = max(keyWidth, lipgloss.Width(k.b.Help().Key))
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		lines := []string{sectionStyle.Render(s.title)}
+		for _, k := range keys {
+			desc := k.desc
+			if desc == "" {
+				desc = k.b.Help().Desc
+			}
+			lines = append(lines, valueStyle.Render(fitCell(k.b.Help().Key, keyWidth, false))+"  "+labelStyle.Render(desc))
+		}
+		blocks = append(blocks, lipgloss.NewStyle().MarginRight(4).MarginBottom(1).Render(joinLines(lines)))
+	}
+
+	// flow the sections into rows as wide as the screen allows
+	var rows, row []string
+	rowWidth := 0
+	for _, b := range blocks {
+		if w := lipgloss.Width(b); len(row) > 0 && rowWidth+w > inner {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		row = append(row, b)
+		rowWidth += lipgloss.Width(b)
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+
+	title := titleLine("\u2328", "Keys", inner+4, lipgloss.Color(colorPrimary))
+	body := strings.TrimRight(lipgloss.JoinVertical(lipgloss.Left, rows...), "\n ")
+	if m.apiToken != "" {
+		body += "\n\n" + labelStyle.Render("API token  ") + valueStyle.Render(m.apiToken)
+	}
+	return detailStyle.Width(width - 2).Render(lipgloss.JoinVertical(lipgloss.Left, title, "", body))
+}
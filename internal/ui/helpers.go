@@ -1,10 +1,12 @@
 package ui
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+	"time"
 
-type ContainerDetail interface {
-	DetailFields() map[string]string
-}
+	"github.com/wosiu6/docky-go/internal/domain"
+)
 
 func TruncateString(s string, max int) string {
 	if len(s) > max {
@@ -19,6 +21,8 @@ func StatusInfo(status string) (color string, icon string, text string) {
 		return colorSuccess, "\u25cf", "RUNNING"
 	case "paused":
 		return colorWarning, "\u275a\u275a", "PAUSED"
+	case domain.StateUnhealthy:
+		return colorDanger, "\u271a", "UNHEALTHY"
 	case "restarting":
 		return colorInfo, "\u21bb", "RESTARTING"
 	case "exited":
@@ -31,3 +35,55 @@ func StatusInfo(status string) (color string, icon string, text string) {
 		return colorDark, "\u2b58", strings.ToUpper(status)
 	}
 }
+
+func HealthInfo(h *domain.Health) (color string, icon string, text string) {
+	if h == nil {
+		return "", "", ""
+	}
+	switch h.Status {
+	case domain.HealthHealthy:
+		return colorSuccess, "\u2665", "HEALTHY"
+	case domain.HealthUnhealthy:
+		return colorDanger, "\u2661", "UNHEALTHY"
+	case domain.HealthStarting:
+		return colorWarning, "\u231b", "STARTING"
+	default:
+		return colorDark, "\u2661", strings.ToUpper(string(h.Status))
+	}
+}
+
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+// FormatBytes renders a byte count with decimal units, like docker stats.
+func FormatBytes(b uint64) string {
+	const unit = 1000
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(b)/float64(div), "kMGTPE"[exp])
+}
+
+// FormatMB renders a size the fetcher reports in MiB.
+func FormatMB(mb uint64) string {
+	if mb >= 1024 {
+		return fmt.Sprintf("%.1fGiB", float64(mb)/1024)
+	}
+	return fmt.Sprintf("%dMiB", mb)
+}
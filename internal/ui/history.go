@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/history"
+)
+
+type HistorySource interface {
+	Query(name string, from, to time.Time) ([]history.Sample, error)
+}
+
+var historyRanges = []struct {
+	label string
+	span  time.Duration
+}{
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"6h", 6 * time.Hour},
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+}
+
+const (
+	defaultHistoryRange = 1
+	historyRefresh      = 10 * time.Second
+)
+
+type historyState struct {
+	open    bool
+	rng     int
+	name    string
+	from    time.Time
+	to      time.Time
+	samples []history.Sample
+	err     error
+	loading bool
+}
+
+type historyMsg struct {
+	name     string
+	rng      int
+	from, to time.Time
+	samples  []history.Sample
+	err      error
+}
+
+// SetHistory enables the history screen.
+func (m *UiModel) SetHistory(h HistorySource) { m.history = h }
+
+// updateHistory handles the keys of the history screen; ok is false for keys
+// it leaves to the main handler, such as quit.
+func (m *UiModel) updateHistory(msg tea.KeyMsg) (cmd tea.Cmd, ok bool) {
+	km := m.keys
+	switch {
+	case key.Matches(msg, km.Back), key.Matches(msg, km.History):
+		m.hist.open = false
+		return nil, true
+	case key.Matches(msg, km.RangePrev):
+		if m.hist.rng > 0 {
+			m.hist.rng--
+			return m.queryHistory(), true
+		}
+		return nil, true
+	case key.Matches(msg, km.RangeNext):
+		if m.hist.rng < len(historyRanges)-1 {
+			m.hist.rng++
+			return m.queryHistory(), true
+		}
+		return nil, true
+	case key.Matches(msg, km.Down):
+		m.moveToContainer(1)
+		m.hist.samples = nil
+		return m.queryHistory(), true
+	case key.Matches(msg, km.Up):
+		m.moveToContainer(-1)
+		m.hist.samples = nil
+		return m.queryHistory(), true
+	}
+	return nil, false
+}
+
+func (m *UiModel) queryHistory() tea.Cmd {
+	c, ok := m.current()
+	if !ok || m.history == nil {
+		return nil
+	}
+	name, rng := baseName(c), m.hist.rng
+	m.hist.name, m.hist.loading = name, true
+	src := m.history
+	return func() tea.Msg {
+		to := time.Now()
+		from := to.Add(-historyRanges[rng].span)
+		samples, err := src.Query(name, from, to)
+		return historyMsg{name: name, rng: rng, from: from, to: to, samples: samples, err: err}
+	}
+}
+
+func (m *UiModel) setHistory(msg historyMsg) {
+	// a slow query for a previous selection or range must not overwrite
+	// the current one
+	if msg.name != m.hist.name || msg.rng != m.hist.rng {
+		return
+	}
+	m.hist.from, m.hist.to = msg.from, msg.to
+	m.hist.samples, m.hist.err, m.hist.loading = msg.samples, msg.err, false
+}
+
+func (m *UiModel) renderHistory() string {
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	height := m.termSize.Height
+	if height <= 0 {
+		height = 30
+	}
+	inner := width - 6
+
+	var tabs []string
+	for i, r := range historyRanges {
+		style := labelStyle.Padding(0, 1)
+		if i == m.hist.rng {
+			style = style.Foreground(lipgloss.Color(colorLogo)).Underline(true)
+		}
+		tabs = append(tabs, style.Render(r.label))
+	}
+	lines := []string{
+		titleLine("\U0001F4C8", "History: "+m.hist.name, inner+4, lipgloss.Color(colorPrimary)),
+		lipgloss.JoinHorizontal(lipgloss.Top, tabs...),
+		"",
+	}
+	switch {
+	case m.hist.err != nil:
+		lines = append(lines, errorStyle.Render(m.hist.err.Error()))
+	case m.hist.loading && m.hist.samples == nil:
+		lines = append(lines, emptyStyle.Render("Loading history..."))
+	case len(m.hist.samples) == 0:
+		lines = append(lines, emptyStyle.Render(fmt.Sprintf("No samples for %s in the last %s.", m.hist.name, historyRanges[m.hist.rng].label)))
+	default:
+		chartH := max((height-14)/2, 3)
+		times := make([]time.Time, len(m.hist.samples))
+		cpu := make([]float64, len(m.hist.samples))
+		mem := make([]float64, len(m.hist.samples))
+		for i, s := range m.hist.samples {
+			times[i], cpu[i], mem[i] = s.Time, s.CPU, float64(s.MemMB)
+		}
+		chartW := max(inner-10, 10)
+		lines = append(lines,
+			renderChart("CPU %", bucketSeries(times, cpu, m.hist.from, m.hist.to, chartW), chartH, colorInfo, func(v float64) string { return fmt.Sprintf("%.1f%%", v) }, m.hist.from, m.hist.to),
+			"",
+			renderChart("Memory", bucketSeries(times, mem, m.hist.from, m.hist.to, chartW), chartH, colorSuccess, func(v float64) string { return fmt.Sprintf("%.0fMB", v) }, m.hist.from, m.hist.to),
+		)
+	}
+	return detailStyle.Width(width - 2).Render(joinLines(lines))
+}
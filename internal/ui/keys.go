@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds a binding per remappable action. ctrl+c always quits and the
+// filter prompt's keys are fixed, since the prompt takes any other key as
+// text.
+type keyMap struct {
+	Quit, Help                                   key.Binding
+	Down, Up, NextPage, PrevPage                 key.Binding
+	Details, Back, Filter, History, Group, Table key.Binding
+	Topology, Disk, Prune                        key.Binding
+	SortNext, SortPrev, SortInvert               key.Binding
+	Restart, StartStop, Pause, Open              key.Binding
+	ColumnNext, ColumnPrev, Wider, Narrower      key.Binding
+	RangePrev, RangeNext                         key.Binding
+}
+
+func binding(help string, keys ...string) key.Binding {
+	var unique []string
+	for _, k := range keys {
+		if !slices.Contains(unique, k) {
+			unique = append(unique, k)
+		}
+	}
+	keys = unique
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keyLabel(keys), help))
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Quit:       binding("quit", "q"),
+		Help:       binding("show all keys", "?"),
+		Down:       binding("next container", "j", "down"),
+		Up:         binding("previous container", "k", "up"),
+		NextPage:   binding("next page", "l", "right"),
+		PrevPage:   binding("previous page", "h", "left"),
+		Details:    binding("details, fold project", "enter"),
+		Back:       binding("back, clear filter", "esc"),
+		Filter:     binding("filter", "/"),
+		History:    binding("history", "H"),
+		Topology:   binding("ports and networks", "n"),
+		Disk:       binding("images, volumes and disk usage", "D"),
+		Prune:      binding("prune", "P"),
+		Group:      binding("group by project", "g"),
+		Table:      binding("cards or table", "t"),
+		SortNext:   binding("next sort key", ">"),
+		SortPrev:   binding("previous sort key", "<"),
+		SortInvert: binding("invert sort", "I"),
+		Restart:    binding("restart", "r"),
+		StartStop:  binding("start or stop", "s"),
+		Pause:      binding("pause or unpause", "p"),
+		Open:       binding("open URL in browser", "o"),
+		ColumnNext: binding("next column", "tab"),
+		ColumnPrev: binding("previous column", "shift+tab"),
+		Wider:      binding("widen column", "+", "="),
+		Narrower:   binding("narrow column", "-"),
+		RangePrev:  binding("shorter range", "h", "left", "["),
+		RangeNext:  binding("longer range", "l", "right", "]"),
+	}
+}
+
+// actions names each binding as it is written in the config.
+func (km *keyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit": &km.Quit, "help": &km.Help,
+		"down": &km.Down, "up": &km.Up, "next-page": &km.NextPage, "prev-page": &km.PrevPage,
+		"details": &km.Details, "back": &km.Back, "filter": &km.Filter, "history": &km.History,
+		"topology": &km.Topology, "disk": &km.Disk, "prune": &km.Prune,
+		"group": &km.Group, "table": &km.Table,
+		"sort-next": &km.SortNext, "sort-prev": &km.SortPrev, "sort-invert": &km.SortInvert,
+		"restart": &km.Restart, "start-stop": &km.StartStop, "pause": &km.Pause, "open": &km.Open,
+		"column-next": &km.ColumnNext, "column-prev": &km.ColumnPrev, "wider": &km.Wider, "narrower": &km.Narrower,
+		"range-prev": &km.RangePrev, "range-next": &km.RangeNext,
+	}
+}
+
+// keyScopes are the sets of actions handled on the same screen, where one
+// key must not mean two things. The history, topology and disk screens
+// handle their own keys before the grid's, so they may reuse them.
+var keyScopes = [][]string{
+	{"quit", "help", "down", "up", "next-page", "prev-page", "details", "back", "filter", "history", "topology", "disk", "group", "table",
+		"sort-next", "sort-prev", "sort-invert", "restart", "start-stop", "pause", "open", "column-next", "column-prev", "wider", "narrower"},
+	{"quit", "help", "back", "history", "down", "up", "range-prev", "range-next"},
+	{"quit", "help", "back", "topology", "down", "up", "next-page", "prev-page"},
+	{"quit", "help", "back", "disk", "down", "up", "next-page", "prev-page", "column-next", "column-prev", "prune"},
+}
+
+func KeyActions() []string {
+	km := defaultKeyMap()
+	return slices.Sorted(maps.Keys(km.actions()))
+}
+
+// SetKeyBindings replaces the keys of actions, e.g. {"quit": ["x"]}; an
+// empty list unbinds the action. It reports every unknown action and every
+// key that ends up bound to two actions on the same screen, and leaves the
+// bindings unchanged if there are any.
+func (m *UiModel) SetKeyBindings(bindings map[string][]string) error {
+	km := defaultKeyMap()
+	actions := km.actions()
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(bindings)) {
+		b, ok := actions[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("keybindings: unknown action %q, expected one of %s", name, strings.Join(KeyActions(), ", ")))
+			continue
+		}
+		keys := bindings[name]
+		if slices.Contains(keys, "") {
+			errs = append(errs, fmt.Errorf("keybindings.%s: empty key", name))
+			continue
+		}
+		*b = binding(b.Help().Desc, keys...)
+		b.SetEnabled(len(keys) > 0)
+	}
+	errs = append(errs, keyConflicts(actions)...)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	m.keys = km
+	return nil
+}
+
+func keyConflicts(actions map[string]*key.Binding) []error {
+	var errs []error
+	seen := map[[2]string]bool{}
+	for _, scope := range keyScopes {
+		owner := map[string]string{}
+		for _, name := range scope {
+			for _, k := range actions[name].Keys() {
+				prev, ok := owner[k]
+				if !ok {
+					owner[k] = name
+					continue
+				}
+				if prev == name {
+					continue
+				}
+				if pair := [2]string{prev, name}; !seen[pair] {
+					seen[pair] = true
+					errs = append(errs, fmt.Errorf("keybindings: %q is bound to both %s and %s", k, prev, name))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+var keySymbols = map[string]string{"up": "↑", "down": "↓", "left": "←", "right": "→", " ": "space"}
+
+func keyLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		if s, ok := keySymbols[k]; ok {
+			k = s
+		}
+		labels[i] = k
+	}
+	return strings.Join(labels, "/")
+}
+
+// keyHint is the footer text for bindings sharing a description, using the
+// first key of each, e.g. "j/k select".
+func keyHint(desc string, bs ...key.Binding) string {
+	var keys []string
+	for _, b := range bs {
+		if b.Enabled() {
+			keys = append(keys, keyLabel(b.Keys()[:1]))
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	return strings.Join(keys, "/") + " " + desc
+}
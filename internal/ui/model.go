@@ -2,9 +2,14 @@ package ui
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/wosiu6/docky-go/internal/docker"
 	"github.com/wosiu6/docky-go/internal/fetcher"
+	"github.com/wosiu6/docky-go/internal/filter"
 )
 
 type FetcherInterface interface {
@@ -18,14 +23,58 @@ type UiModel struct {
 	loading  bool
 	termSize tea.WindowSizeMsg
 	page     int
+	cursor   int
+	selected string
+	detail   bool
+	actions  docker.ActionClient
+	notice   string
+	history  HistorySource
+	hist     historyState
+	networks NetworkSource
+	topo     topologyState
+	disk     DiskSource
+	prune    docker.PruneClient
+	dsk      diskState
+
+	entries   []gridEntry
+	visible   []int
+	grouped   bool
+	collapsed map[string]bool
+
+	host     string
+	apiToken string
+	filter   *filter.Filter
+	prompt   filterPrompt
+
+	sortKey     int
+	sortReverse bool
+
+	table tableState
+	keys  keyMap
+	help  bool
 }
 
 type RefreshMsg struct{}
 
-func New(fetcher FetcherInterface) *UiModel { return &UiModel{fetcher: fetcher, loading: true} }
+func New(fetcher FetcherInterface) *UiModel {
+	return &UiModel{fetcher: fetcher, loading: true, grouped: true, collapsed: map[string]bool{}, hist: historyState{rng: defaultHistoryRange}, table: newTableState(), keys: defaultKeyMap()}
+}
 func (m *UiModel) SetItems(items []fetcher.ContainerInfo) {
 	m.items = items
 	m.loading = false
+	m.rebuild()
+}
+
+// SetActions enables the container action keys; without it the UI is
+// read-only.
+func (m *UiModel) SetActions(a docker.ActionClient) { m.actions = a }
+
+// SetAPIToken shows a generated API token, which would otherwise be cleared
+// from the terminal with the TUI's first frame. It stays in the help
+// overlay after the startup notice goes.
+func (m *UiModel) SetAPIToken(token string) {
+	m.apiToken = token
+	m.notice = "API token: " + token
 }
 
 func (m *UiModel) Init() tea.Cmd { return tea.ClearScreen }
@@ -33,48 +82,283 @@ func (m *UiModel) Init() tea.Cmd { return tea.ClearScreen }
 func (m *UiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		m.notice = ""
+		if m.prompt.open {
+			return m, m.updatePrompt(msg)
+		}
+		if m.dsk.confirm != "" {
+			return m, m.updateConfirm(msg)
+		}
+		if m.hist.open {
+			if cmd, ok := m.updateHistory(msg); ok {
+				return m, cmd
+			}
+		}
+		if m.topo.open {
+			if cmd, ok := m.updateTopology(msg); ok {
+				return m, cmd
+			}
+		}
+		if m.dsk.open {
+			if cmd, ok := m.updateDisk(msg); ok {
+				return m, cmd
+			}
+		}
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		km := m.keys
+		if m.help {
+			switch {
+			case key.Matches(msg, km.Quit):
+				return m, tea.Quit
+			case key.Matches(msg, km.Help), key.Matches(msg, km.Back):
+				m.help = false
+			}
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, km.Quit):
 			return m, tea.Quit
-		case "right", "l":
+		case key.Matches(msg, km.Help):
+			m.help = true
+			return m, nil
+		case key.Matches(msg, km.Back):
+			if !m.detail && m.filter != nil {
+				m.SetFilter(nil)
+			}
+			m.detail = false
+			return m, nil
+		case key.Matches(msg, km.Filter):
+			m.openPrompt()
+			return m, nil
+		case key.Matches(msg, km.History):
+			if _, ok := m.current(); ok && m.history != nil {
+				m.hist.open, m.hist.samples, m.hist.err = true, nil, nil
+				return m, m.queryHistory()
+			}
+			return m, nil
+		case key.Matches(msg, km.Topology):
+			return m, m.openTopology()
+		case key.Matches(msg, km.Disk):
+			return m, m.openDisk()
+		case key.Matches(msg, km.Details):
+			if project, ok := m.currentProject(); ok {
+				m.toggleCollapsed(project)
+			} else if _, ok := m.current(); ok {
+				m.detail = !m.detail
+			}
+			return m, nil
+		case key.Matches(msg, km.SortNext):
+			m.cycleSort(1)
+			return m, nil
+		case key.Matches(msg, km.SortPrev):
+			m.cycleSort(-1)
+			return m, nil
+		case key.Matches(msg, km.SortInvert):
+			m.sortReverse = !m.sortReverse
+			m.rebuild()
+			return m, nil
+		case key.Matches(msg, km.Group):
+			m.grouped = !m.grouped
+			m.rebuild()
+			return m, nil
+		case key.Matches(msg, km.Table):
+			m.toggleTable()
+			return m, nil
+		case key.Matches(msg, km.ColumnNext):
+			if m.table.on {
+				m.focusColumn(1)
+			}
+			return m, nil
+		case key.Matches(msg, km.ColumnPrev):
+			if m.table.on {
+				m.focusColumn(-1)
+			}
+			return m, nil
+		case key.Matches(msg, km.Wider):
+			if m.table.on {
+				m.resizeColumn(1)
+			}
+			return m, nil
+		case key.Matches(msg, km.Narrower):
+			if m.table.on {
+				m.resizeColumn(-1)
+			}
+			return m, nil
+		case key.Matches(msg, km.Down):
+			m.moveCursor(1)
+			return m, nil
+		case key.Matches(msg, km.Up):
+			m.moveCursor(-1)
+			return m, nil
+		case key.Matches(msg, km.NextPage):
 			m.nextPage()
 			return m, nil
-		case "left", "h":
+		case key.Matches(msg, km.PrevPage):
 			m.prevPage()
 			return m, nil
+		case key.Matches(msg, km.Restart):
+			if project, ok := m.currentProject(); ok {
+				return m, m.actProject(project, docker.ActionRestart)
+			}
+			return m, m.act(docker.ActionRestart)
+		case key.Matches(msg, km.StartStop):
+			if project, ok := m.currentProject(); ok {
+				if m.projectRunning(project) {
+					return m, m.actProject(project, docker.ActionStop)
+				}
+				return m, m.actProject(project, docker.ActionStart)
+			}
+			if c, ok := m.current(); ok && c.Status == "running" {
+				return m, m.act(docker.ActionStop)
+			}
+			return m, m.act(docker.ActionStart)
+		case key.Matches(msg, km.Open):
+			return m, m.openURL()
+		case key.Matches(msg, km.Pause):
+			if c, ok := m.current(); ok && c.Status == "paused" {
+				return m, m.act(docker.ActionUnpause)
+			}
+			return m, m.act(docker.ActionPause)
 		}
+	case networksMsg:
+		m.setNetworks(msg)
+		return m, nil
+	case diskMsg:
+		m.setDisk(msg)
+		return m, nil
+	case pruneResultMsg:
+		m.notice = msg.String()
+		if m.dsk.open {
+			return m, m.queryDisk()
+		}
+		return m, nil
+	case historyMsg:
+		m.setHistory(msg)
+		return m, nil
+	case actionResultMsg:
+		m.notice = msg.String()
+		return m, nil
+	case openResultMsg:
+		m.notice = msg.String()
+		return m, nil
 	case tea.WindowSizeMsg:
 		m.termSize = msg
 		return m, nil
 	case RefreshMsg:
+		if m.hist.open && !m.hist.loading && time.Since(m.hist.to) > historyRefresh {
+			return m, m.queryHistory()
+		}
+		if m.topo.open && !m.topo.loading && time.Since(m.topo.fetched) > topologyRefresh {
+			return m, m.queryNetworks()
+		}
+		if m.dsk.open && !m.dsk.loading && time.Since(m.dsk.fetched) > diskRefresh {
+			return m, m.queryDisk()
+		}
 		return m, nil
 	}
 	return m, nil
 }
 
+func (m *UiModel) moveCursor(delta int) {
+	if len(m.entries) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.entries)-1)
+	m.syncSelection()
+}
+
+// moveToContainer moves like moveCursor but skips project headers, for
+// screens that only make sense for a container.
+func (m *UiModel) moveToContainer(delta int) {
+	for i := m.cursor + delta; i >= 0 && i < len(m.entries); i += delta {
+		if !m.entries[i].header() {
+			m.cursor = i
+			m.syncSelection()
+			return
+		}
+	}
+}
+
+// syncSelection keeps the selected ID and page in step with the cursor so the
+// selection follows a container across refreshes and stays visible.
+func (m *UiModel) syncSelection() {
+	if len(m.entries) == 0 {
+		m.selected = ""
+		m.detail = false
+		return
+	}
+	m.selected = m.entryKey(m.entries[m.cursor])
+	if m.entries[m.cursor].header() {
+		m.detail = false
+	}
+	starts := m.pageStarts()
+	m.page = 0
+	for i, start := range starts {
+		if start <= m.cursor {
+			m.page = i
+		}
+	}
+}
+
+func (m *UiModel) current() (fetcher.ContainerInfo, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.entries) || m.entries[m.cursor].header() {
+		return fetcher.ContainerInfo{}, false
+	}
+	return m.items[m.entries[m.cursor].item], true
+}
+
 func (m *UiModel) nextPage() {
+	if m.table.on {
+		m.moveCursor(m.tableRows(1))
+		return
+	}
 	maxPages := m.totalPages()
 	if m.page < maxPages-1 {
 		m.page++
+		m.pageCursor()
 	}
 }
 
 func (m *UiModel) prevPage() {
+	if m.table.on {
+		m.moveCursor(-m.tableRows(1))
+		return
+	}
 	if m.page > 0 {
 		m.page--
+		m.pageCursor()
+	}
+}
+
+func (m *UiModel) pageCursor() {
+	starts := m.pageStarts()
+	if len(m.entries) == 0 || m.page >= len(starts) {
+		return
 	}
+	m.cursor = starts[m.page]
+	m.selected = m.entryKey(m.entries[m.cursor])
 }
 
 func (m *UiModel) totalPages() int {
-	if len(m.items) == 0 {
+	if len(m.entries) == 0 || m.table.on {
 		return 1
 	}
+	return len(m.pageStarts())
+}
+
+// pageStarts is the index of the first entry on each page of the grid.
+func (m *UiModel) pageStarts() []int {
+	if m.hasHeaders() {
+		return m.groupedPageStarts()
+	}
 	_, _, perPage := m.layoutSpec()
-	if perPage <= 0 {
-		return 1
+	starts := []int{0}
+	for i := perPage; i < len(m.entries); i += perPage {
+		starts = append(starts, i)
 	}
-	pages := max((len(m.items)+perPage-1)/perPage, 1)
-	return pages
+	return starts
 }
 
 func (m *UiModel) layoutSpec() (int, int, int) {
@@ -99,3 +383,29 @@ func (m *UiModel) layoutSpec() (int, int, int) {
 	maxItems := cols * rows
 	return cols, rows, maxItems
 }
+
+type actionResultMsg struct {
+	name   string
+	action docker.Action
+	err    error
+}
+
+func (r actionResultMsg) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("%s %s failed: %v", r.action, r.name, r.err)
+	}
+	return fmt.Sprintf("%s %s: ok", r.action, r.name)
+}
+
+func (m *UiModel) act(action docker.Action) tea.Cmd {
+	c, ok := m.current()
+	if !ok || m.actions == nil {
+		return nil
+	}
+	name := baseName(c)
+	m.notice = fmt.Sprintf("%s %s...", action, name)
+	client := m.actions
+	return func() tea.Msg {
+		return actionResultMsg{name: name, action: action, err: client.ContainerAction(context.Background(), c.ID, action)}
+	}
+}
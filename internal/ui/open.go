@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type openResultMsg struct {
+	url string
+	err error
+}
+
+func (r openResultMsg) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("open %s failed: %v", r.url, r.err)
+	}
+	return "opened " + r.url
+}
+
+// openCommand starts the system's handler for url.
+func openCommand(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
+
+func (m *UiModel) openURL() tea.Cmd {
+	c, ok := m.current()
+	if !ok {
+		return nil
+	}
+	if c.URL == "" {
+		m.notice = baseName(c) + " has no URL"
+		return nil
+	}
+	url := c.URL
+	return func() tea.Msg {
+		cmd := openCommand(url)
+		err := cmd.Start()
+		if err == nil {
+			go cmd.Wait()
+		}
+		return openResultMsg{url: url, err: err}
+	}
+}
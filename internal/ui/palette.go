@@ -0,0 +1,77 @@
+package ui
+
+import "github.com/wosiu6/docky-go/internal/domain"
+
+// Appearance is the card colour and icon for a container type. The web
+// dashboard reads the same table through Palette so both front-ends agree.
+type Appearance struct {
+	Color string `json:"color"`
+	Icon  string `json:"icon"`
+}
+
+const genericIcon = "\U0001F4E6"
+
+func AppearanceFor(t domain.ContainerType) Appearance {
+	a := cardTypes[t].look
+	brand := a.Color != ""
+	if !brand {
+		a.Color = colorGeneric
+	}
+	if a.Icon == "" {
+		a.Icon = genericIcon
+	}
+	switch {
+	case current.Mono:
+		a.Color = ""
+	case current.Types[t] != "":
+		a.Color = current.Types[t]
+	case brand && current.TypeDefault != "":
+		a.Color = current.TypeDefault
+	}
+	return a
+}
+
+// StateAppearance mirrors StatusInfo and HealthInfo for non-terminal clients.
+type StateAppearance struct {
+	Color string `json:"color"`
+	Icon  string `json:"icon"`
+	Text  string `json:"text"`
+}
+
+type PaletteSet struct {
+	Generic Appearance                 `json:"generic"`
+	Types   map[string]Appearance      `json:"types"`
+	States  map[string]StateAppearance `json:"states"`
+	Health  map[string]StateAppearance `json:"health"`
+	Alert   string                     `json:"alert"`
+	Accent  string                     `json:"accent"`
+}
+
+func Palette() PaletteSet {
+	// the browser has colour even when the terminal doesn't
+	if current.Mono {
+		saved := current
+		applyTheme(darkTheme)
+		defer applyTheme(saved)
+	}
+	p := PaletteSet{
+		Generic: AppearanceFor(domain.ContainerTypeGeneric),
+		Types:   make(map[string]Appearance, len(cardTypes)),
+		States:  map[string]StateAppearance{},
+		Health:  map[string]StateAppearance{},
+		Alert:   colorDanger,
+		Accent:  colorLogo,
+	}
+	for _, t := range cardTypeNames() {
+		p.Types[string(t)] = AppearanceFor(t)
+	}
+	for _, s := range []string{"running", "paused", domain.StateUnhealthy, "restarting", "exited", "created", "dead"} {
+		c, i, t := StatusInfo(s)
+		p.States[s] = StateAppearance{c, i, t}
+	}
+	for _, s := range []domain.HealthStatus{domain.HealthHealthy, domain.HealthUnhealthy, domain.HealthStarting} {
+		c, i, t := HealthInfo(&domain.Health{Status: s})
+		p.Health[string(s)] = StateAppearance{c, i, t}
+	}
+	return p
+}
@@ -3,33 +3,14 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/fetcher"
 )
 
-func (m *UiModel) renderContainer(container fetcher.ContainerInfo, width, height int) string {
-	switch container.Type {
-	case domain.ContainerTypePostgreSQL:
-		return renderPostgres(container, width, height)
-	case domain.ContainerTypeMinecraft:
-		return renderMinecraft(container, width, height)
-	case domain.ContainerTypeTraefik:
-		return renderTraefik(container, width, height)
-	case domain.ContainerTypeRedis:
-		return renderRedis(container, width, height)
-	case domain.ContainerTypeMinio:
-		return renderMinio(container, width, height)
-	case domain.ContainerTypeGrafana:
-		return renderGrafana(container, width, height)
-	case domain.ContainerTypePrometheus:
-		return renderPrometheus(container, width, height)
-	default:
-		return renderGeneric(container, width, height)
-	}
-}
-
 func baseName(c fetcher.ContainerInfo) string {
 	name := "unnamed"
 	if len(c.Names) > 0 {
@@ -51,11 +32,65 @@ func titleLine(icon, name string, width int, borderColor lipgloss.Color) string
 
 func statusLine(c fetcher.ContainerInfo) string {
 	colorHex, statusIcon, statusText := StatusInfo(c.Status)
-	return statusStyle.Foreground(lipgloss.Color(colorHex)).Render(fmt.Sprintf("%s %s", statusIcon, statusText))
+	line := statusStyle.Foreground(lipgloss.Color(colorHex)).Render(fmt.Sprintf("%s %s", statusIcon, statusText)) + healthBadge(c.Health) + lifecycleBadges(c.Lifecycle) + alertBadge(c.Alerts)
+	if lc := lifecycleLine(c); lc != "" {
+		line += "\n" + lc
+	}
+	return line
+}
+
+func lifecycleBadges(l domain.Lifecycle) string {
+	var out string
+	if l.RestartStorm {
+		out += healthBadgeStyle.Background(lipgloss.Color(colorDanger)).Render("\u21bb RESTART STORM")
+	}
+	if l.OOMKilled {
+		out += healthBadgeStyle.Background(lipgloss.Color(colorWarning)).Render("OOM")
+	}
+	return out
 }
 
-func combinedStatsLine(c fetcher.ContainerInfo, format string) string {
-	return statsStyle.Render(fmt.Sprintf(format, c.CPUPercent, c.Mem))
+func alertBadge(alerts []domain.Alert) string {
+	if len(alerts) == 0 {
+		return ""
+	}
+	return healthBadgeStyle.Background(lipgloss.Color(colorDanger)).Render(fmt.Sprintf("\u26a0 %d ALERT", len(alerts)))
+}
+
+// lifecycleLine shows uptime for running containers and the last exit reason
+// for everything else, plus the restart count once there has been one.
+func lifecycleLine(c fetcher.ContainerInfo) string {
+	l := c.Lifecycle
+	var parts []string
+	if up := l.Uptime(c.Status, time.Now()); up > 0 {
+		parts = append(parts, "up "+FormatDuration(up))
+	} else if reason := l.ExitReason(); reason != "" {
+		if !l.FinishedAt.IsZero() {
+			reason += " " + FormatDuration(time.Since(l.FinishedAt)) + " ago"
+		}
+		parts = append(parts, reason)
+	}
+	if l.RestartCount > 0 || l.RecentRestarts > 0 {
+		parts = append(parts, fmt.Sprintf("%d restarts", max(l.RestartCount, l.RecentRestarts)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return labelStyle.Render(strings.Join(parts, " \u00b7 "))
+}
+
+func healthBadge(h *domain.Health) string {
+	colorHex, icon, text := HealthInfo(h)
+	if text == "" {
+		return ""
+	}
+	return healthBadgeStyle.Background(lipgloss.Color(colorHex)).Render(fmt.Sprintf("%s %s", icon, text))
+}
+
+// statsLine is the CPU and memory line every card shows, in the same format
+// as the project headers.
+func statsLine(c fetcher.ContainerInfo) string {
+	return statsStyle.Render(fmt.Sprintf("CPU: %.1f%%  MEM: %dMB", c.CPUPercent, c.Mem))
 }
 
 func imageLine(c fetcher.ContainerInfo, width int) string {
@@ -67,6 +102,29 @@ func idLine(c fetcher.ContainerInfo) string {
 	return labelStyle.Render("ID:     ") + valueStyle.Render(shortID(c.ID))
 }
 
+// linkLines are the card lines linking to the container: its public
+// hostnames when Traefik routes to it, and its URL unless that is the first
+// of those hosts.
+func linkLines(c fetcher.ContainerInfo, width int) []string {
+	var lines []string
+	if l := hostsLine(c.Routes, width-4); l != "" {
+		lines = append(lines, l)
+		if len(c.Routes) > 0 && c.URL == c.Routes[0].URL() {
+			return lines
+		}
+	}
+	if c.URL != "" {
+		lines = append(lines, labelStyle.Render("URL: ")+hyperlink(c.URL, valueStyle.Render(TruncateString(c.URL, max(width-9, 4)))))
+	}
+	return lines
+}
+
+// hyperlink wraps text in an OSC 8 link; terminals without support show the
+// text alone.
+func hyperlink(url, text string) string {
+	return ansi.SetHyperlink(url) + text + ansi.ResetHyperlink()
+}
+
 func joinLines(lines []string) string {
 	var b strings.Builder
 	for i, l := range lines {
@@ -9,23 +9,29 @@ import (
 )
 
 func renderGeneric(container fetcher.ContainerInfo, width, height int) string {
-	colorBorder := lipgloss.Color(colorGeneric)
-	icon := "\U0001F4E6"
+	look := AppearanceFor(container.Type)
+	colorBorder := lipgloss.Color(look.Color)
+	icon := look.Icon
 	typeLabel := string(container.Type)
 	name := TruncateString(baseName(container), width-4)
 	var b strings.Builder
 	b.WriteString(titleLine(icon, name, width, colorBorder) + "\n")
 	b.WriteString(lipgloss.NewStyle().Foreground(colorBorder).Bold(true).Render(fmt.Sprintf("\u25cf %s", typeLabel)) + "\n")
-	colorHex, statusIcon, statusText := StatusInfo(container.Status)
-	b.WriteString(statusStyle.Foreground(lipgloss.Color(colorHex)).Render(fmt.Sprintf("%s %s", statusIcon, statusText)) + "\n\n")
+	b.WriteString(statusLine(container) + "\n\n")
 	b.WriteString(labelStyle.Render("CPU:    ") + statsStyle.Render(fmt.Sprintf("%.1f%%", container.CPUPercent)) + "\n")
 	b.WriteString(labelStyle.Render("Memory: ") + statsStyle.Render(fmt.Sprintf("%d MB", container.Mem)) + "\n\n")
 	image := TruncateString(container.Image, width-12)
 	b.WriteString(labelStyle.Render("Image:  ") + valueStyle.Render(image) + "\n")
-	b.WriteString(labelStyle.Render("ID:     ") + valueStyle.Render(shortID(container.ID)) + "\n\n")
+	b.WriteString(labelStyle.Render("ID:     ") + valueStyle.Render(shortID(container.ID)) + "\n")
+	for _, l := range linkLines(container, width) {
+		b.WriteString(l + "\n")
+	}
+	b.WriteString("\n")
 	if detail := container.Specific; detail != nil {
-		for k, v := range detail.DetailFields() {
-			b.WriteString(labelStyle.Render(k+": ") + valueStyle.Render(v) + "\n")
+		for _, f := range detail.DetailFields() {
+			if !f.Minor {
+				b.WriteString(fieldLine(f, width-4) + "\n")
+			}
 		}
 	}
 	style := containerStyle.BorderForeground(colorBorder).Width(width)
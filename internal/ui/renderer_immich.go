@@ -0,0 +1,17 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+func RenderImmich(c fetcher.ContainerInfo, w, h int) string {
+	var body []string
+	for _, dep := range []struct{ label, host, port string }{{"DB", "DB Host", "DB Port"}, {"Redis", "Redis Host", "Redis Port"}} {
+		if addr := hostPort(DetailField(c, dep.host), DetailField(c, dep.port)); addr != "" {
+			body = append(body, labelStyle.Render(dep.label+": ")+valueStyle.Render(TruncateString(addr, w-12)))
+		}
+	}
+	return RenderCard(c, domain.ContainerTypeImmich, w, h, lipgloss.ThickBorder(), versioned("Immich", c), body)
+}
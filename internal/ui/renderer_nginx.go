@@ -0,0 +1,13 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+func RenderNginx(c fetcher.ContainerInfo, w, h int) string {
+	body := CardFields(c, w, "Ports")
+	body = append(body, imageLine(c, w))
+	return RenderCard(c, domain.ContainerTypeNginx, w, h, lipgloss.RoundedBorder(), "", body)
+}
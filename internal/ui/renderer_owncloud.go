@@ -0,0 +1,15 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+func RenderOwnCloud(c fetcher.ContainerInfo, w, h int) string {
+	var body []string
+	if db := databaseOf(c); db != "" {
+		body = append(body, labelStyle.Render("DB: ")+valueStyle.Render(TruncateString(db, w-10)))
+	}
+	return RenderCard(c, domain.ContainerTypeOwnCloud, w, h, lipgloss.RoundedBorder(), versioned("ownCloud", c), body)
+}
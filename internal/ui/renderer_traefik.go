@@ -1,22 +1,37 @@
 package ui
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
 	"github.com/wosiu6/docky-go/internal/fetcher"
 )
 
-func renderTraefik(c fetcher.ContainerInfo, w, h int) string {
+func RenderTraefik(c fetcher.ContainerInfo, w, h int) string {
 	name := baseName(c)
-	colorBorder := lipgloss.Color(colorTraefik)
-	icon := "\U0001F6A6"
-	var entrypoints string
-	if d := c.Specific; d != nil {
-		entrypoints = d.DetailFields()["Entrypoints"]
-	}
-	lines := []string{titleLine(icon, name, w, colorBorder), statusLine(c), combinedStatsLine(c, "CPU %.1f%% MEM %dMB")}
+	look := AppearanceFor(domain.ContainerTypeTraefik)
+	colorBorder := lipgloss.Color(look.Color)
+	icon := look.Icon
+	entrypoints := DetailField(c, "Entrypoints")
+	lines := []string{titleLine(icon, name, w, colorBorder), statusLine(c), statsLine(c)}
 	if entrypoints != "" {
 		lines = append(lines, labelStyle.Render("Entrypoints: ")+valueStyle.Render(entrypoints))
 	}
+	if t := c.Routing; t != nil {
+		problems := 0
+		for _, r := range t.Routes {
+			if len(r.Errors) > 0 || r.Status == "warning" || r.Status == "disabled" {
+				problems++
+			}
+		}
+		routers := valueStyle.Render(fmt.Sprint(len(t.Routes)))
+		if problems > 0 {
+			routers += " " + dangerText(fmt.Sprintf("(%d failing)", problems))
+		}
+		lines = append(lines, labelStyle.Render("Routers: ")+routers)
+	}
+	lines = append(lines, linkLines(c, w)...)
 	style := containerStyle.BorderForeground(colorBorder).BorderStyle(lipgloss.DoubleBorder()).Width(w)
 	if h > 0 {
 		style = style.Height(h)
@@ -0,0 +1,18 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+func RenderVaultwarden(c fetcher.ContainerInfo, w, h int) string {
+	// the admin token is a secret even with masking off, so only say
+	// whether the admin panel is enabled
+	admin := "disabled"
+	if DetailField(c, "Admin Token") != "" {
+		admin = "enabled"
+	}
+	body := append(CardFields(c, w, "Port"), labelStyle.Render("Admin panel: ")+valueStyle.Render(admin))
+	return RenderCard(c, domain.ContainerTypeVaultwarden, w, h, lipgloss.DoubleBorder(), versioned("Vaultwarden", c), body)
+}
@@ -0,0 +1,15 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+func RenderWordPress(c fetcher.ContainerInfo, w, h int) string {
+	body := CardFields(c, w, "Port")
+	if db := databaseOf(c); db != "" {
+		body = append(body, labelStyle.Render("DB: ")+valueStyle.Render(TruncateString(db, w-10)))
+	}
+	return RenderCard(c, domain.ContainerTypeWordPress, w, h, lipgloss.RoundedBorder(), versioned("WordPress", c), body)
+}
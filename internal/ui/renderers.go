@@ -1 +1,160 @@
 package ui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+// CardRenderer draws a container's card at the given size; a height of 0
+// lets the card take what it needs.
+type CardRenderer func(c fetcher.ContainerInfo, width, height int) string
+
+// cardType is how the UI draws a container type: the brand colour and icon
+// used on cards, in the table and by the web dashboard, and the card layout.
+type cardType struct {
+	look   Appearance
+	render CardRenderer
+}
+
+var cardTypes = map[domain.ContainerType]cardType{}
+
+// RegisterCard is called by the plugin registry for every container type.
+// A nil render gives the type the generic card.
+func RegisterCard(t domain.ContainerType, look Appearance, render CardRenderer) {
+	cardTypes[t] = cardType{look, render}
+}
+
+// cardTypeNames lists the registered types, for validating theme overrides.
+func cardTypeNames() []domain.ContainerType {
+	return slices.Sorted(maps.Keys(cardTypes))
+}
+
+func (m *UiModel) renderContainer(container fetcher.ContainerInfo, width, height int) string {
+	if ct := cardTypes[container.Type]; ct.render != nil {
+		return ct.render(container, width, height)
+	}
+	return renderGeneric(container, width, height)
+}
+
+func DetailField(c fetcher.ContainerInfo, key string) string {
+	if c.Specific == nil {
+		return ""
+	}
+	f, _ := domain.LookupField(c.Specific.DetailFields(), key)
+	return f.String()
+}
+
+// versioned is a headline such as "Kibana 8.13.0", or empty when the
+// version is unknown.
+func versioned(product string, c fetcher.ContainerInfo) string {
+	if v := DetailField(c, "Version"); v != "" {
+		return product + " " + v
+	}
+	return ""
+}
+
+// databaseOf is the "DB Name" and "DB Host" fields of an app as name @ host.
+func databaseOf(c fetcher.ContainerInfo) string {
+	var parts []string
+	for _, k := range []string{"DB Name", "DB Host"} {
+		if v := DetailField(c, k); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, " @ ")
+}
+
+func hostPort(host, port string) string {
+	if host == "" || port == "" {
+		return host
+	}
+	return host + ":" + port
+}
+
+// CardFields renders the detail fields named by keys as label lines, in that
+// order, skipping the ones the container doesn't set.
+func CardFields(c fetcher.ContainerInfo, width int, keys ...string) []string {
+	if c.Specific == nil {
+		return nil
+	}
+	fields := c.Specific.DetailFields()
+	var lines []string
+	for _, k := range keys {
+		if f, ok := domain.LookupField(fields, k); ok {
+			lines = append(lines, fieldLine(f, width-4))
+		}
+	}
+	return lines
+}
+
+// fieldLine renders f as "Name: value", truncating the value to fit width.
+func fieldLine(f domain.Field, width int) string {
+	return labelStyle.Render(f.Name+": ") + valueStyle.Render(TruncateString(f.String(), max(width-len(f.Name)-2, 4)))
+}
+
+// RenderCard is the common card layout: title, an optional headline in the
+// brand colour, status and stats, then the type's own lines.
+func RenderCard(c fetcher.ContainerInfo, t domain.ContainerType, width, height int, border lipgloss.Border, headline string, body []string) string {
+	look := AppearanceFor(t)
+	colorBorder := lipgloss.Color(look.Color)
+	lines := []string{titleLine(look.Icon, TruncateString(baseName(c), width-4), width, colorBorder)}
+	if headline != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorBorder).Bold(true).Render(TruncateString(headline, width-4)))
+	}
+	lines = append(lines, statusLine(c), statsLine(c))
+	lines = append(lines, linkLines(c, width)...)
+	lines = append(lines, body...)
+	style := containerStyle.BorderForeground(colorBorder).BorderStyle(border).Width(width)
+	if height > 0 {
+		style = style.Height(height)
+	}
+	return style.Render(joinLines(lines))
+}
+
+// CardSpec describes a card in RenderCard's layout as data, for types that
+// only differ in their border, headline and fields.
+type CardSpec struct {
+	Border lipgloss.Border
+	// Headline is the brand-coloured line under the title; nil or an empty
+	// result leaves it out.
+	Headline func(c fetcher.ContainerInfo) string
+	// Fields are the detail fields listed under the stats, in order.
+	Fields []string
+}
+
+// Renderer draws t's cards from the spec.
+func (s CardSpec) Renderer(t domain.ContainerType) CardRenderer {
+	return func(c fetcher.ContainerInfo, width, height int) string {
+		var headline string
+		if s.Headline != nil {
+			headline = s.Headline(c)
+		}
+		return RenderCard(c, t, width, height, s.Border, headline, CardFields(c, width, s.Fields...))
+	}
+}
+
+// FixedHeadline heads every card with text.
+func FixedHeadline(text string) func(fetcher.ContainerInfo) string {
+	return func(fetcher.ContainerInfo) string { return text }
+}
+
+// VersionHeadline heads the card with the product and its "Version" field.
+func VersionHeadline(product string) func(fetcher.ContainerInfo) string {
+	return func(c fetcher.ContainerInfo) string { return versioned(product, c) }
+}
+
+// FieldHeadline heads the card with prefix and the detail field key, when
+// the container sets it.
+func FieldHeadline(prefix, key string) func(fetcher.ContainerInfo) string {
+	return func(c fetcher.ContainerInfo) string {
+		if v := DetailField(c, key); v != "" {
+			return prefix + v
+		}
+		return ""
+	}
+}
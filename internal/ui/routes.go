@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+// hostsLine lists the public hostnames of routes, each a link, as many as
+// fit in width with a count of the rest.
+func hostsLine(routes []domain.Route, width int) string {
+	type host struct{ name, url string }
+	var hosts []host
+	seen := map[string]bool{}
+	for _, r := range routes {
+		for _, h := range r.Hosts {
+			if !seen[h] {
+				seen[h] = true
+				hosts = append(hosts, host{h, domain.Route{Hosts: []string{h}, Path: r.Path, TLS: r.TLS}.URL()})
+			}
+		}
+	}
+	if len(hosts) == 0 {
+		return ""
+	}
+	label := "Hosts: "
+	if len(hosts) == 1 {
+		label = "Host: "
+	}
+	used, room := 0, width-len(label)
+	var parts []string
+	for i, h := range hosts {
+		more := 0
+		if left := len(hosts) - i - 1; left > 0 {
+			more = len(fmt.Sprintf(", +%d", left))
+		}
+		name := h.name
+		if i == 0 {
+			name = TruncateString(name, max(room-more, 4))
+		} else if used+2+len(name)+more > room {
+			parts = append(parts, labelStyle.Render(fmt.Sprintf("+%d", len(hosts)-i)))
+			break
+		}
+		parts = append(parts, hyperlink(h.url, valueStyle.Render(name)))
+		used += len(name) + 2
+	}
+	return labelStyle.Render(label) + strings.Join(parts, labelStyle.Render(", "))
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func dangerText(s string) string {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(colorDanger)).Render(s)
+}
+
+func routeStatus(status string) string {
+	color := colorTextDim
+	switch status {
+	case "enabled":
+		color = colorSuccess
+	case "warning":
+		color = colorWarning
+	case "disabled":
+		color = colorDanger
+	case "":
+		status = "-"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(status)
+}
+
+// routeRows renders routes as a table of address, router, container and
+// status, with each router's errors underneath.
+func routeRows(routes []domain.Route, width int, withContainer bool) []string {
+	addrWidth, routerWidth, containerWidth := max(width/3, 16), max(width/4, 12), 0
+	if withContainer {
+		containerWidth = max(width/5, 10)
+	}
+	var lines []string
+	for _, r := range routes {
+		addr := strings.Join(r.Hosts, ", ") + r.Path
+		if addr == "" {
+			addr = r.Rule
+		}
+		if u := r.URL(); u != "" {
+			addr = hyperlink(u, valueStyle.Render(fitCell(addr, addrWidth, false)))
+		} else {
+			addr = valueStyle.Render(fitCell(addr, addrWidth, false))
+		}
+		router := r.Router
+		if r.Provider != "" {
+			router += "@" + r.Provider
+		}
+		row := addr + " " + labelStyle.Render(fitCell(router, routerWidth, false))
+		if withContainer {
+			container := r.Container
+			if container == "" {
+				container = "-"
+			}
+			row += " " + valueStyle.Render(fitCell(container, containerWidth, false))
+		}
+		lines = append(lines, row+" "+routeStatus(r.Status))
+		for _, e := range r.Errors {
+			lines = append(lines, "  "+dangerText(ansi.Truncate(e, max(width-2, 4), "…")))
+		}
+	}
+	return lines
+}
+
+// detailRoutes shows the routers pointing at c and, for a Traefik
+// container, everything it routes.
+func detailRoutes(c fetcher.ContainerInfo, width int) []string {
+	var lines []string
+	if len(c.Routes) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Routes"))
+		lines = append(lines, routeRows(c.Routes, width, false)...)
+	}
+	t := c.Routing
+	if t == nil {
+		return lines
+	}
+	lines = append(lines, "", sectionStyle.Render("Routing table"))
+	if o := t.Overview; o != nil {
+		counts := func(name string, n domain.TraefikCounts) string {
+			s := plural(n.Total, name)
+			if n.Warnings > 0 || n.Errors > 0 {
+				s += " (" + plural(n.Warnings, "warning") + ", " + plural(n.Errors, "error") + ")"
+			}
+			return s
+		}
+		lines = append(lines, valueStyle.Render(strings.Join([]string{
+			counts("router", o.Routers), counts("service", o.Services), counts("middleware", o.Middlewares),
+		}, ", ")))
+	}
+	if t.Err != "" {
+		lines = append(lines, dangerText(TruncateString("Traefik API: "+t.Err, width)))
+	}
+	if len(t.Routes) == 0 {
+		return append(lines, emptyStyle.Render("no routers"))
+	}
+	return append(lines, routeRows(t.Routes, width, true)...)
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+// sortKey compares two containers in the key's natural direction: names and
+// types A to Z, problem states first, and the biggest consumers first for
+// the numeric keys.
+type sortKey struct {
+	name    string
+	compare func(a, b fetcher.ContainerInfo, now time.Time) int
+}
+
+var sortKeys = []sortKey{
+	{"name", func(a, b fetcher.ContainerInfo, _ time.Time) int { return strings.Compare(sortName(a), sortName(b)) }},
+	{"cpu", func(a, b fetcher.ContainerInfo, _ time.Time) int { return cmp.Compare(b.CPUPercent, a.CPUPercent) }},
+	{"memory", func(a, b fetcher.ContainerInfo, _ time.Time) int { return cmp.Compare(b.Mem, a.Mem) }},
+	{"status", func(a, b fetcher.ContainerInfo, _ time.Time) int {
+		return cmp.Compare(domain.StateRank(domain.EffectiveState(a.Status, a.Health)), domain.StateRank(domain.EffectiveState(b.Status, b.Health)))
+	}},
+	{"uptime", func(a, b fetcher.ContainerInfo, now time.Time) int {
+		return cmp.Compare(b.Lifecycle.Uptime(b.Status, now), a.Lifecycle.Uptime(a.Status, now))
+	}},
+	{"type", func(a, b fetcher.ContainerInfo, _ time.Time) int {
+		return strings.Compare(string(a.Type), string(b.Type))
+	}},
+	{"restarts", func(a, b fetcher.ContainerInfo, _ time.Time) int {
+		return cmp.Compare(b.Lifecycle.RestartCount, a.Lifecycle.RestartCount)
+	}},
+	{"net i/o", func(a, b fetcher.ContainerInfo, _ time.Time) int {
+		return cmp.Compare(b.NetRx+b.NetTx, a.NetRx+a.NetTx)
+	}},
+}
+
+func sortName(c fetcher.ContainerInfo) string { return strings.ToLower(baseName(c)) }
+
+// sortVisible orders the filtered items by the selected key. Ties fall back
+// to name and then ID, so cards with equal values keep their place across
+// refreshes instead of swapping around.
+func (m *UiModel) sortVisible() {
+	key, now := sortKeys[m.sortKey], time.Now()
+	slices.SortStableFunc(m.visible, func(i, j int) int {
+		a, b := m.items[i], m.items[j]
+		c := key.compare(a, b, now)
+		if m.sortReverse {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		if c = strings.Compare(sortName(a), sortName(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+func (m *UiModel) cycleSort(delta int) {
+	m.sortKey = (m.sortKey + delta + len(sortKeys)) % len(sortKeys)
+	m.sortReverse = false
+	m.rebuild()
+}
+
+func (m *UiModel) sortLabel() string {
+	arrow := "↓"
+	if m.sortReverse {
+		arrow = "↑"
+	}
+	return "sort: " + sortKeys[m.sortKey].name + " " + arrow
+}
+
+// SetSort selects the sort key by name, as listed by SortKeys.
+func (m *UiModel) SetSort(name string, reverse bool) error {
+	i := slices.IndexFunc(sortKeys, func(k sortKey) bool { return k.name == name })
+	if i < 0 {
+		return fmt.Errorf("unknown sort key %q, expected one of %s", name, strings.Join(SortKeys(), ", "))
+	}
+	m.sortKey, m.sortReverse = i, reverse
+	m.rebuild()
+	return nil
+}
+
+func SortKeys() []string {
+	out := make([]string, len(sortKeys))
+	for i, k := range sortKeys {
+		out[i] = k.name
+	}
+	return out
+}
@@ -2,67 +2,116 @@ package ui
 
 import "github.com/charmbracelet/lipgloss"
 
+// Theme colours, set by applyTheme. Per-type accents come from the plugin
+// registry and only change through a theme's Types.
 var (
-	colorPrimary = "#1E90FF"
-	colorSuccess = "#28A745"
-	colorWarning = "#FFC107"
-	colorDanger  = "#DC3545"
-	colorInfo    = "#17A2B8"
-	colorLight   = "#F8F9FA"
-	colorDark    = "#343A40"
-
-	colorGeneric     = "#874BFD"
-	colorGenericDark = "#602bc9ff"
-	colorText        = "#FAFAFA"
-	colorTextDim     = "#d1d1d1ff"
-
-	colorLogo       = "#FDF500"
-	colorTraefik    = "#24A1C1"
-	colorRedis      = "#D82C20"
-	colorPostgres   = "#336791"
-	colorMySQL      = "#4479A1"
-	colorMongoDB    = "#47A248"
-	colorNginx      = "#009639"
-	colorMinio      = "#FFBD2E"
-	colorMinecraft  = "#55AA55"
-	colorGrafana    = "#F46800"
-	colorPrometheus = "#E6522C"
+	colorPrimary string
+	colorSuccess string
+	colorWarning string
+	colorDanger  string
+	colorInfo    string
+	colorLight   string
+	colorDark    string
 
+	colorGeneric     string
+	colorGenericDark string
+	colorText        string
+	colorTextDim     string
+
+	colorLogo     string
+	colorOnAccent string
+)
+
+var (
+	containerStyle   lipgloss.Style
+	titleStyle       lipgloss.Style
+	labelStyle       lipgloss.Style
+	valueStyle       lipgloss.Style
+	statsStyle       lipgloss.Style
+	statusStyle      lipgloss.Style
+	healthBadgeStyle lipgloss.Style
+	selectedStyle    lipgloss.Style
+	alertCardStyle   lipgloss.Style
+	alertPanelStyle  lipgloss.Style
+	detailStyle      lipgloss.Style
+	sectionStyle     lipgloss.Style
+	errorStyle       lipgloss.Style
+	emptyStyle       lipgloss.Style
+)
+
+func init() { applyTheme(darkTheme) }
+
+// buildStyles recreates the shared styles from the current colours.
+func buildStyles() {
 	containerStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			Padding(0, 1).
-			MarginRight(1).
-			MarginBottom(0)
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1).
+		MarginRight(1).
+		MarginBottom(0)
 
 	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color(colorText)).
-			Padding(0, 1).
-			MarginBottom(0)
+		Bold(true).
+		Foreground(lipgloss.Color(colorOnAccent)).
+		Padding(0, 1).
+		MarginBottom(0)
 
 	labelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colorTextDim)).
-			Bold(true)
+		Foreground(lipgloss.Color(colorTextDim)).
+		Bold(true)
 
 	valueStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colorText))
+		Foreground(lipgloss.Color(colorText))
 
 	statsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colorInfo)).
-			Bold(true)
+		Foreground(lipgloss.Color(colorInfo)).
+		Bold(true)
 
 	statusStyle = lipgloss.NewStyle().
-			Bold(true).
-			Padding(0, 1)
+		Bold(true).
+		Padding(0, 1)
+
+	healthBadgeStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(colorOnAccent)).
+		Padding(0, 1)
+
+	selectedStyle = lipgloss.NewStyle().
+		Border(lipgloss.ThickBorder(), false, false, false, true).
+		BorderForeground(lipgloss.Color(colorLogo))
+
+	// without colour an alerting card needs a different border to stand
+	// apart from the selected one
+	alertBorder := lipgloss.ThickBorder()
+	if current.Mono {
+		alertBorder = lipgloss.DoubleBorder()
+	}
+	alertCardStyle = lipgloss.NewStyle().
+		Border(alertBorder, false, false, false, true).
+		BorderForeground(lipgloss.Color(colorDanger))
+
+	alertPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colorDanger)).
+		Padding(0, 1)
+
+	detailStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colorPrimary)).
+		Padding(0, 1)
+
+	sectionStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(colorPrimary)).
+		Bold(true).
+		Underline(true)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colorDanger)).
-			Bold(true).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(colorDanger)).
-			Padding(1, 2)
+		Foreground(lipgloss.Color(colorDanger)).
+		Bold(true).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(colorDanger)).
+		Padding(1, 2)
 
 	emptyStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colorDark)).
-			Italic(true)
-)
+		Foreground(lipgloss.Color(colorDark)).
+		Italic(true)
+}
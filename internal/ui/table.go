@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+// tableColumn is one column of the table view. sort names the sort key the
+// column shows an arrow for, and cell returns the plain text and colour of a
+// container's value.
+type tableColumn struct {
+	title string
+	width int
+	right bool
+	sort  string
+	cell  func(c fetcher.ContainerInfo, now time.Time) (string, string)
+}
+
+const (
+	minColumnWidth = 2
+	maxColumnWidth = 80
+)
+
+var tableColumns = []tableColumn{
+	{"NAME", 24, false, "name", func(c fetcher.ContainerInfo, _ time.Time) (string, string) { return baseName(c), colorText }},
+	{"T", 2, false, "type", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		look := AppearanceFor(c.Type)
+		return look.Icon, look.Color
+	}},
+	{"STATUS", 14, false, "status", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		color, icon, text := StatusInfo(domain.EffectiveState(c.Status, c.Health))
+		if _, hIcon, _ := HealthInfo(c.Health); hIcon != "" && c.Health.Status != domain.HealthUnhealthy {
+			text += " " + hIcon
+		}
+		return icon + " " + strings.ToLower(text), color
+	}},
+	{"CPU %", 7, true, "cpu", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		return fmt.Sprintf("%.2f%%", c.CPUPercent), colorInfo
+	}},
+	{"MEM USAGE / LIMIT", 19, true, "memory", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		if c.MemLimit == 0 {
+			return FormatMB(c.Mem), colorInfo
+		}
+		return FormatMB(c.Mem) + " / " + FormatMB(c.MemLimit), colorInfo
+	}},
+	{"MEM %", 6, true, "", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		if c.MemLimit == 0 {
+			return "-", colorTextDim
+		}
+		return fmt.Sprintf("%.1f%%", float64(c.Mem)/float64(c.MemLimit)*100), colorInfo
+	}},
+	{"NET I/O", 17, true, "net i/o", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		return FormatBytes(c.NetRx) + " / " + FormatBytes(c.NetTx), colorTextDim
+	}},
+	{"BLOCK I/O", 17, true, "", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		return FormatBytes(c.BlockRead) + " / " + FormatBytes(c.BlockWrite), colorTextDim
+	}},
+	{"PIDS", 5, true, "", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		return fmt.Sprint(c.PIDs), colorTextDim
+	}},
+	{"UPTIME", 8, true, "uptime", func(c fetcher.ContainerInfo, now time.Time) (string, string) {
+		if up := c.Lifecycle.Uptime(c.Status, now); up > 0 {
+			return FormatDuration(up), colorTextDim
+		}
+		return "-", colorTextDim
+	}},
+	{"PORTS", 28, false, "", func(c fetcher.ContainerInfo, _ time.Time) (string, string) {
+		ports := make([]string, 0, len(c.Ports))
+		for _, p := range c.Ports {
+			ports = append(ports, p.String())
+		}
+		return strings.Join(ports, ", "), colorTextDim
+	}},
+}
+
+// tableState is the table view's own state; selection, filter and sort are
+// shared with the card grid through the model.
+type tableState struct {
+	on     bool
+	col    int
+	widths []int
+	offset int
+}
+
+func newTableState() tableState {
+	t := tableState{widths: make([]int, len(tableColumns))}
+	for i, col := range tableColumns {
+		t.widths[i] = col.width
+	}
+	return t
+}
+
+// SetTableView starts the UI in the table view instead of the cards.
+func (m *UiModel) SetTableView(on bool) { m.table.on = on }
+
+func (m *UiModel) toggleTable() {
+	m.table.on = !m.table.on
+	m.syncSelection()
+}
+
+func (m *UiModel) focusColumn(delta int) {
+	m.table.col = (m.table.col + delta + len(tableColumns)) % len(tableColumns)
+}
+
+func (m *UiModel) resizeColumn(delta int) {
+	w := &m.table.widths[m.table.col]
+	*w = min(max(*w+delta, minColumnWidth), maxColumnWidth)
+}
+
+// tableRows is how many entries fit below the header row, given the height
+// taken by whatever is drawn under the table.
+func (m *UiModel) tableRows(below int) int {
+	height := m.termSize.Height
+	if height <= 0 {
+		height = 30
+	}
+	return max(height-1-below, 1)
+}
+
+// scrollTable moves the offset just enough to keep the cursor on screen.
+func (m *UiModel) scrollTable(rows int) {
+	if m.cursor < m.table.offset {
+		m.table.offset = m.cursor
+	}
+	if m.cursor >= m.table.offset+rows {
+		m.table.offset = m.cursor - rows + 1
+	}
+	m.table.offset = min(max(m.table.offset, 0), max(len(m.entries)-rows, 0))
+}
+
+func (m *UiModel) renderTable(below int, width int) string {
+	rows := m.tableRows(below)
+	m.scrollTable(rows)
+	lines := []string{m.renderTableHeader()}
+	now := time.Now()
+	end := min(m.table.offset+rows, len(m.entries))
+	for i := m.table.offset; i < end; i++ {
+		e := m.entries[i]
+		if e.header() {
+			lines = append(lines, m.renderTableProject(e.project, i == m.cursor))
+		} else {
+			lines = append(lines, m.renderTableRow(m.items[e.item], i == m.cursor, now))
+		}
+	}
+	for i := range lines {
+		lines[i] = ansi.Truncate(lines[i], width, "")
+	}
+	return joinLines(lines)
+}
+
+func (m *UiModel) renderTableHeader() string {
+	cells := []string{" "}
+	for i, col := range tableColumns {
+		title := col.title
+		if col.sort != "" && col.sort == sortKeys[m.sortKey].name {
+			if m.sortReverse {
+				title += "↑"
+			} else {
+				title += "↓"
+			}
+		}
+		style := labelStyle
+		if m.table.on && i == m.table.col {
+			style = style.Foreground(lipgloss.Color(colorLogo)).Underline(true)
+		}
+		cells = append(cells, style.Render(fitCell(title, m.table.widths[i], col.right)))
+	}
+	return strings.Join(cells, " ")
+}
+
+func (m *UiModel) renderTableRow(c fetcher.ContainerInfo, selected bool, now time.Time) string {
+	cells := []string{tableGutter(selected, len(c.Alerts) > 0)}
+	for i, col := range tableColumns {
+		text, color := col.cell(c, now)
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(selected)
+		cells = append(cells, style.Render(fitCell(text, m.table.widths[i], col.right)))
+	}
+	return strings.Join(cells, " ")
+}
+
+// renderTableProject fills a header row with the project's totals, so the
+// columns still line up under the titles.
+func (m *UiModel) renderTableProject(project string, selected bool) string {
+	t := m.projectTotals(project)
+	values := map[string][2]string{
+		"NAME":              {t.arrow + " " + projectLabel(project), colorLogo},
+		"STATUS":            {fmt.Sprintf("%d/%d running", t.running, t.total), t.countColor},
+		"CPU %":             {fmt.Sprintf("%.2f%%", t.cpu), colorInfo},
+		"MEM USAGE / LIMIT": {FormatMB(t.mem), colorInfo},
+	}
+	cells := []string{tableGutter(selected, false)}
+	for i, col := range tableColumns {
+		v := values[col.title]
+		cells = append(cells, lipgloss.NewStyle().Foreground(lipgloss.Color(v[1])).Bold(true).Render(fitCell(v[0], m.table.widths[i], col.right)))
+	}
+	return strings.Join(cells, " ")
+}
+
+func tableGutter(selected, alerting bool) string {
+	switch {
+	case selected:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(colorLogo)).Render("▌")
+	case alerting && current.Mono:
+		return "!"
+	case alerting:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(colorDanger)).Render("▌")
+	default:
+		return " "
+	}
+}
+
+// fitCell truncates or pads s to exactly width terminal cells.
+func fitCell(s string, width int, right bool) string {
+	s = ansi.Truncate(s, width, "…")
+	pad := strings.Repeat(" ", max(width-ansi.StringWidth(s), 0))
+	if right {
+		return pad + s
+	}
+	return s + pad
+}
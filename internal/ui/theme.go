@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/wosiu6/docky-go/internal/domain"
+)
+
+// Theme is a set of colours, as hex (#RRGGBB) or ANSI (0-255) values.
+type Theme struct {
+	Primary     string
+	Success     string
+	Warning     string
+	Danger      string
+	Info        string
+	Light       string
+	Dark        string
+	Generic     string
+	GenericDark string
+	Text        string
+	TextDim     string
+	Accent      string
+	// OnAccent is text drawn on a coloured background, like card titles.
+	OnAccent string
+	// Types overrides the brand accent of container types. TypeDefault, when
+	// set, replaces the brand colour of every type not listed in Types.
+	Types       map[domain.ContainerType]string
+	TypeDefault string
+	// Mono drops all colour and marks alerts by shape instead.
+	Mono bool
+}
+
+const themeAuto = "auto"
+
+var (
+	darkTheme = Theme{
+		Primary: "#1E90FF", Success: "#28A745", Warning: "#FFC107", Danger: "#DC3545", Info: "#17A2B8",
+		Light: "#F8F9FA", Dark: "#343A40", Generic: "#874BFD", GenericDark: "#602bc9ff",
+		Text: "#FAFAFA", TextDim: "#d1d1d1ff", Accent: "#FDF500", OnAccent: "#FAFAFA",
+	}
+	lightTheme = Theme{
+		Primary: "#0969DA", Success: "#1A7F37", Warning: "#9A6700", Danger: "#CF222E", Info: "#1B7C83",
+		Light: "#57606A", Dark: "#6E7781", Generic: "#8250DF", GenericDark: "#6639BA",
+		Text: "#1F2328", TextDim: "#57606A", Accent: "#BC4C00", OnAccent: "#FFFFFF",
+		Types: map[domain.ContainerType]string{domain.ContainerTypeMinio: "#B07800", domain.ContainerTypeMinecraft: "#2E7D32"},
+	}
+	// highContrastTheme sticks to the 16 ANSI colours so it renders exactly
+	// on terminals without 256-colour support.
+	highContrastTheme = Theme{
+		Primary: "12", Success: "10", Warning: "11", Danger: "9", Info: "14",
+		Light: "15", Dark: "7", Generic: "13", GenericDark: "5",
+		Text: "15", TextDim: "15", Accent: "11", OnAccent: "0", TypeDefault: "14",
+	}
+	solarizedTheme = Theme{
+		Primary: "#268BD2", Success: "#859900", Warning: "#B58900", Danger: "#DC322F", Info: "#2AA198",
+		Light: "#EEE8D5", Dark: "#586E75", Generic: "#6C71C4", GenericDark: "#D33682",
+		Text: "#93A1A1", TextDim: "#839496", Accent: "#CB4B16", OnAccent: "#FDF6E3",
+	}
+	monoTheme = Theme{Mono: true}
+)
+
+var themes = map[string]Theme{
+	"dark":          darkTheme,
+	"light":         lightTheme,
+	"high-contrast": highContrastTheme,
+	"solarized":     solarizedTheme,
+	"mono":          monoTheme,
+}
+
+var builtinThemes = slices.Collect(maps.Keys(themes))
+
+var current Theme
+
+// Themes lists the theme names SetTheme accepts.
+func Themes() []string {
+	return append(slices.Sorted(maps.Keys(themes)), themeAuto)
+}
+
+// SetTheme switches the TUI colours. auto follows the terminal like
+// lipgloss's adaptive colours do: high-contrast on 16-colour terminals, then
+// light or dark by background. NO_COLOR forces mono whatever the name.
+func SetTheme(name string) error {
+	t, ok := themes[name]
+	if name == themeAuto {
+		t, ok = autoTheme(), true
+	}
+	if !ok {
+		return fmt.Errorf("unknown theme %q, expected one of %s", name, strings.Join(Themes(), ", "))
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		t = monoTheme
+	}
+	applyTheme(t)
+	return nil
+}
+
+func autoTheme() Theme {
+	switch lipgloss.ColorProfile() {
+	case termenv.Ascii:
+		return monoTheme
+	case termenv.ANSI:
+		return highContrastTheme
+	}
+	if !lipgloss.HasDarkBackground() {
+		return lightTheme
+	}
+	return darkTheme
+}
+
+func applyTheme(t Theme) {
+	current = t
+	colorPrimary, colorSuccess, colorWarning, colorDanger, colorInfo = t.Primary, t.Success, t.Warning, t.Danger, t.Info
+	colorLight, colorDark = t.Light, t.Dark
+	colorGeneric, colorGenericDark = t.Generic, t.GenericDark
+	colorText, colorTextDim, colorLogo, colorOnAccent = t.Text, t.TextDim, t.Accent, t.OnAccent
+	buildStyles()
+}
+
+// RegisterTheme adds a user theme: base (dark when empty) with colours
+// overridden by key, e.g. {"text": "#000000"}, and per-type accents keyed by
+// container type.
+func RegisterTheme(name, base string, colors, types map[string]string) error {
+	if name == "" || name == themeAuto || slices.Contains(builtinThemes, name) {
+		return fmt.Errorf("theme name %q is reserved", name)
+	}
+	if base == "" {
+		base = "dark"
+	}
+	t, ok := themes[base]
+	if !ok || base == name {
+		return fmt.Errorf("unknown base theme %q", base)
+	}
+	t.Mono = false
+	fields := map[string]*string{
+		"primary": &t.Primary, "success": &t.Success, "warning": &t.Warning, "danger": &t.Danger, "info": &t.Info,
+		"light": &t.Light, "dark": &t.Dark, "generic": &t.Generic, "generic_dark": &t.GenericDark,
+		"text": &t.Text, "text_dim": &t.TextDim, "accent": &t.Accent, "on_accent": &t.OnAccent, "type_default": &t.TypeDefault,
+	}
+	for _, k := range slices.Sorted(maps.Keys(colors)) {
+		field, ok := fields[k]
+		if !ok {
+			return fmt.Errorf("unknown colour %q, expected one of %s", k, strings.Join(slices.Sorted(maps.Keys(fields)), ", "))
+		}
+		if !validColor(colors[k]) {
+			return fmt.Errorf("%s: %q is not a #RRGGBB or 0-255 colour", k, colors[k])
+		}
+		*field = colors[k]
+	}
+	t.Types = maps.Clone(t.Types)
+	if t.Types == nil && len(types) > 0 {
+		t.Types = map[domain.ContainerType]string{}
+	}
+	for _, k := range slices.Sorted(maps.Keys(types)) {
+		if !slices.Contains(cardTypeNames(), domain.ContainerType(k)) {
+			return fmt.Errorf("unknown container type %q", k)
+		}
+		if !validColor(types[k]) {
+			return fmt.Errorf("%s: %q is not a #RRGGBB or 0-255 colour", k, types[k])
+		}
+		t.Types[domain.ContainerType(k)] = types[k]
+	}
+	themes[name] = t
+	return nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+func validColor(c string) bool {
+	if hexColor.MatchString(c) {
+		return true
+	}
+	n, err := strconv.Atoi(c)
+	return err == nil && n >= 0 && n <= 255
+}
@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/wosiu6/docky-go/internal/domain"
+	"github.com/wosiu6/docky-go/internal/fetcher"
+)
+
+// NetworkSource lists the user-defined networks; fetcher.Fetcher implements
+// it.
+type NetworkSource interface {
+	Networks(ctx context.Context) ([]domain.Network, error)
+}
+
+const topologyRefresh = 10 * time.Second
+
+type topologyState struct {
+	open     bool
+	networks []domain.Network
+	err      error
+	loading  bool
+	fetched  time.Time
+	scroll   int
+}
+
+type networksMsg struct {
+	networks []domain.Network
+	err      error
+}
+
+// SetNetworks lists networks on the ports and networks screen; without it
+// the screen only shows ports.
+func (m *UiModel) SetNetworks(n NetworkSource) { m.networks = n }
+
+// updateTopology handles the keys of the ports and networks screen; ok is
+// false for keys it leaves to the main handler.
+func (m *UiModel) updateTopology(msg tea.KeyMsg) (cmd tea.Cmd, ok bool) {
+	km := m.keys
+	_, page := m.topologyLines()
+	switch {
+	case key.Matches(msg, km.Back), key.Matches(msg, km.Topology):
+		m.topo.open = false
+	case key.Matches(msg, km.Down):
+		m.scrollTopology(1)
+	case key.Matches(msg, km.Up):
+		m.scrollTopology(-1)
+	case key.Matches(msg, km.NextPage):
+		m.scrollTopology(page)
+	case key.Matches(msg, km.PrevPage):
+		m.scrollTopology(-page)
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+func (m *UiModel) openTopology() tea.Cmd {
+	m.topo.open, m.topo.scroll = true, 0
+	return m.queryNetworks()
+}
+
+func (m *UiModel) queryNetworks() tea.Cmd {
+	if m.networks == nil {
+		return nil
+	}
+	m.topo.loading = true
+	src := m.networks
+	return func() tea.Msg {
+		networks, err := src.Networks(context.Background())
+		return networksMsg{networks: networks, err: err}
+	}
+}
+
+func (m *UiModel) setNetworks(msg networksMsg) {
+	m.topo.networks, m.topo.err = msg.networks, msg.err
+	m.topo.loading, m.topo.fetched = false, time.Now()
+}
+
+func (m *UiModel) scrollTopology(delta int) {
+	lines, page := m.topologyLines()
+	m.topo.scroll = min(max(m.topo.scroll+delta, 0), max(len(lines)-page, 0))
+}
+
+// topologyLines is the body of the screen and how many lines of it fit.
+func (m *UiModel) topologyLines() ([]string, int) {
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	height := m.termSize.Height
+	if height <= 0 {
+		height = 30
+	}
+	inner := width - 6
+	containers := make([]domain.Container, len(m.items))
+	for i, c := range m.items {
+		containers[i] = c.Domain()
+	}
+
+	lines := []string{sectionStyle.Render("Published ports")}
+	bindings := domain.Bindings(containers)
+	if len(bindings) == 0 {
+		lines = append(lines, emptyStyle.Render("no published ports"))
+	}
+	hostWidth, containerWidth := max(inner/3, 22), max(inner/3, 20)
+	conflicts := 0
+	for _, b := range bindings {
+		ip := b.IP
+		if ip == "" {
+			ip = "0.0.0.0"
+		}
+		row := valueStyle.Render(fitCell(net.JoinHostPort(ip, strconv.Itoa(int(b.PublicPort))), hostWidth, false)) +
+			labelStyle.Render(" → ") +
+			valueStyle.Render(fitCell(fmt.Sprintf("%s:%d/%s", b.Container, b.PrivatePort, b.Type), containerWidth, false))
+		if b.Conflict {
+			conflicts++
+			row += " " + dangerText("conflict")
+		}
+		lines = append(lines, row)
+	}
+	if conflicts > 0 {
+		lines = append(lines, dangerText(plural(conflicts, "binding")+" share a host port"))
+	}
+
+	lines = append(lines, "", sectionStyle.Render("Networks"))
+	switch {
+	case m.networks == nil:
+		lines = append(lines, emptyStyle.Render("networks are not available"))
+	case m.topo.err != nil:
+		lines = append(lines, dangerText(TruncateString(m.topo.err.Error(), inner)))
+	case m.topo.loading && m.topo.networks == nil:
+		lines = append(lines, emptyStyle.Render("Loading networks..."))
+	case len(m.topo.networks) == 0:
+		lines = append(lines, emptyStyle.Render("no user-defined networks"))
+	}
+	networks := append([]domain.Network(nil), m.topo.networks...)
+	domain.AttachMembers(networks, containers)
+	nameWidth, ipWidth := max(inner/4, 16), 16
+	for i, n := range networks {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		about := []string{n.Driver}
+		if n.Scope != "" && n.Scope != "local" {
+			about = append(about, n.Scope)
+		}
+		about = append(about, n.Subnets...)
+		if n.Internal {
+			about = append(about, "internal")
+		}
+		about = append(about, plural(len(n.Members), "container"))
+		lines = append(lines, valueStyle.Bold(true).Render(n.Name)+labelStyle.Render("  "+strings.Join(about, ", ")))
+		for _, mb := range n.Members {
+			row := "  " + valueStyle.Render(fitCell(mb.Container, nameWidth, false)) + " " + statsStyle.Render(fitCell(mb.IPv4, ipWidth, false))
+			if len(mb.Aliases) > 0 {
+				row += " " + labelStyle.Render(ansi.Truncate("aliases: "+strings.Join(mb.Aliases, ", "), max(inner-nameWidth-ipWidth-4, 8), "…"))
+			}
+			lines = append(lines, row)
+		}
+	}
+	// title, blank line, borders and footer
+	return lines, max(height-5, 1)
+}
+
+func (m *UiModel) renderTopology() string {
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	lines, page := m.topologyLines()
+	m.topo.scroll = min(m.topo.scroll, max(len(lines)-page, 0))
+	title := titleLine("\U0001F310", "Ports & networks", width-2, lipgloss.Color(colorPrimary))
+	if end := min(m.topo.scroll+page, len(lines)); len(lines) > page {
+		lines = lines[m.topo.scroll:end]
+	}
+	return detailStyle.Width(width - 2).Render(joinLines(append([]string{title, ""}, lines...)))
+}
+
+// detailNetworks lists the networks c is attached to.
+func detailNetworks(c fetcher.ContainerInfo, width int) []string {
+	if len(c.Networks) == 0 {
+		return nil
+	}
+	lines := []string{"", sectionStyle.Render("Networks")}
+	nameWidth := max(width/4, 12)
+	for _, e := range c.Networks {
+		row := valueStyle.Render(fitCell(e.Network, nameWidth, false)) + " " + statsStyle.Render(fitCell(e.IPv4, 16, false))
+		if len(e.Aliases) > 0 {
+			row += " " + labelStyle.Render(ansi.Truncate("aliases: "+strings.Join(e.Aliases, ", "), max(width-nameWidth-18, 8), "…"))
+		}
+		lines = append(lines, row)
+	}
+	return lines
+}
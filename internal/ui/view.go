@@ -5,12 +5,16 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/wosiu6/docky-go/internal/fetcher"
 )
 
 func (m *UiModel) View() string {
 	if m.lastErr != nil {
 		return errorStyle.Render(fmt.Sprintf("\u274c Error: %v", m.lastErr))
 	}
+	if m.help {
+		return lipgloss.JoinVertical(lipgloss.Left, m.renderHelp(), m.renderFooter())
+	}
 	if m.loading {
 		return logo() + "\n\nLoading containers..."
 	}
@@ -19,32 +23,100 @@ func (m *UiModel) View() string {
 		return emptyStyle.Render(renderString)
 	}
 
-	cols, _, perPage := m.layoutSpec()
-	if perPage < cols {
-		perPage = cols
+	if m.hist.open {
+		return lipgloss.JoinVertical(lipgloss.Left, m.renderHistory(), m.renderFooter())
+	}
+	if m.topo.open {
+		return lipgloss.JoinVertical(lipgloss.Left, m.renderTopology(), m.renderFooter())
+	}
+	if m.dsk.open {
+		return lipgloss.JoinVertical(lipgloss.Left, m.renderDisk(), m.renderFooter())
+	}
+	if m.detail {
+		if c, ok := m.current(); ok {
+			return lipgloss.JoinVertical(lipgloss.Left, m.renderDetail(c), m.renderFooter())
+		}
+	}
+
+	if m.table.on {
+		return m.viewTable()
 	}
+
+	cols, _, _ := m.layoutSpec()
 	width := m.termSize.Width
 	if width <= 0 {
 		width = 120
 	}
 
 	boxWidth := (width / cols) - 4
-	columnContents := make([][]string, cols)
-	columnHeights := make([]int, cols)
 
-	start := m.page * perPage
-	if start >= len(m.items) {
-		start = 0
+	starts := m.pageStarts()
+	if m.page >= len(starts) {
 		m.page = 0
 	}
-	end := start + perPage
-	if end > len(m.items) {
-		end = len(m.items)
+	start, end := starts[m.page], len(m.entries)
+	if m.page+1 < len(starts) {
+		end = starts[m.page+1]
 	}
-	visible := m.items[start:end]
+	visible := m.entries[start:end]
 
-	for _, container := range visible {
-		box := m.renderContainer(container, boxWidth, 0)
+	var grid string
+	if len(m.entries) == 0 {
+		grid = emptyStyle.Render("No containers match the filter.")
+	} else if m.hasHeaders() {
+		grid = m.renderGroupedGrid(visible, start, cols, boxWidth, width)
+	} else {
+		grid = m.renderMasonry(visible, start, cols, boxWidth)
+	}
+
+	footer := m.renderFooter()
+	if panel := m.renderAlertPanel(); panel != "" {
+		return lipgloss.JoinVertical(lipgloss.Left, grid, panel, footer)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, grid, footer)
+}
+
+func (m *UiModel) viewTable() string {
+	width := m.termSize.Width
+	if width <= 0 {
+		width = 120
+	}
+	footer := m.renderFooter()
+	parts := []string{footer}
+	if panel := m.renderAlertPanel(); panel != "" {
+		parts = []string{panel, footer}
+	}
+	below := 0
+	for _, p := range parts {
+		below += lipgloss.Height(p)
+	}
+	grid := emptyStyle.Render("No containers match the filter.")
+	if len(m.entries) > 0 {
+		grid = m.renderTable(below, width)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{grid}, parts...)...)
+}
+
+func (m *UiModel) hasHeaders() bool {
+	return len(m.entries) > 0 && m.entries[0].header()
+}
+
+func (m *UiModel) renderCard(c fetcher.ContainerInfo, boxWidth int, selected bool) string {
+	box := m.renderContainer(c, boxWidth, 0)
+	if selected {
+		return selectedStyle.Render(box)
+	} else if len(c.Alerts) > 0 {
+		return alertCardStyle.Render(box)
+	}
+	return box
+}
+
+// renderMasonry places each card in the currently shortest column.
+func (m *UiModel) renderMasonry(visible []gridEntry, start, cols, boxWidth int) string {
+	columnContents := make([][]string, cols)
+	columnHeights := make([]int, cols)
+	for i, e := range visible {
+		box := m.renderCard(m.items[e.item], boxWidth, start+i == m.cursor)
 		minIdx := 0
 		minHeight := columnHeights[0]
 		for i := 1; i < cols; i++ {
@@ -62,29 +134,111 @@ func (m *UiModel) View() string {
 		columnsRendered = append(columnsRendered, lipgloss.JoinVertical(lipgloss.Left, columnContents[i]...))
 	}
 
-	grid := lipgloss.JoinHorizontal(lipgloss.Top, columnsRendered...)
-
-	footer := m.renderFooter()
-	return lipgloss.JoinVertical(lipgloss.Left, grid, footer)
+	return lipgloss.JoinHorizontal(lipgloss.Top, columnsRendered...)
 }
 
 func (m *UiModel) renderFooter() string {
 	const sep = " │ "
+	km := m.keys
 
 	quit := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(colorDanger)).
-		Render("q quit")
+		Render(keyHint("quit", km.Quit))
 
-	if m.totalPages() <= 1 {
+	navStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colorTextDim))
+	hints := func(hs ...string) string {
+		var out []string
+		for _, h := range hs {
+			if h != "" {
+				out = append(out, navStyle.Render(h))
+			}
+		}
+		return strings.Join(out, sep)
+	}
+
+	if m.prompt.open {
+		return lipgloss.NewStyle().Width(m.termSize.Width).Render(m.renderPrompt())
+	}
+	if m.help {
+		return lipgloss.NewStyle().
+			Width(m.termSize.Width).
+			Render(hints(keyHint("close", km.Help), keyHint("back", km.Back)) + sep + quit)
+	}
+	if m.hist.open {
+		return lipgloss.NewStyle().
+			Width(m.termSize.Width).
+			Render(hints(keyHint("range", km.RangePrev, km.RangeNext), keyHint("container", km.Down, km.Up), keyHint("back", km.Back), keyHint("keys", km.Help)) + sep + quit)
+	}
+	if m.dsk.confirm != "" {
+		return lipgloss.NewStyle().
+			Width(m.termSize.Width).
+			Render(dangerText(m.confirmText()))
+	}
+	if m.dsk.open {
+		prune := ""
+		if m.prune != nil && m.dsk.tab < 2 {
+			prune = keyHint("prune", km.Prune)
+		}
+		line := hints(keyHint("switch", km.ColumnNext, km.ColumnPrev), keyHint("scroll", km.Down, km.Up), prune, keyHint("back", km.Back), keyHint("keys", km.Help)) + sep + quit
+		if m.notice != "" {
+			line += sep + lipgloss.NewStyle().Foreground(lipgloss.Color(colorInfo)).Render(m.notice)
+		}
+		return lipgloss.NewStyle().Width(m.termSize.Width).Render(line)
+	}
+	if m.topo.open {
+		return lipgloss.NewStyle().
+			Width(m.termSize.Width).
+			Render(hints(keyHint("scroll", km.Down, km.Up), keyHint("page", km.PrevPage, km.NextPage), keyHint("back", km.Back), keyHint("keys", km.Help)) + sep + quit)
+	}
+	open := ""
+	if c, ok := m.current(); ok && c.URL != "" {
+		open = keyHint("open", km.Open)
+	}
+	if m.detail {
 		return lipgloss.NewStyle().
 			Width(m.termSize.Width).
-			Render(quit)
+			Render(hints(keyHint("back", km.Back), open, keyHint("keys", km.Help)) + sep + quit)
 	}
 
-	navStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(colorTextDim))
+	parts := []string{keyHint("select", km.Down, km.Up), keyHint("details", km.Details), keyHint("filter", km.Filter)}
+	if m.table.on {
+		parts = append([]string{fmt.Sprintf("%d/%d", min(m.cursor+1, len(m.entries)), len(m.entries))}, parts...)
+		parts = append(parts, keyHint("page", km.PrevPage, km.NextPage),
+			joinHints("  ", keyHint("column", km.ColumnNext), keyHint("width", km.Wider, km.Narrower)), keyHint("cards", km.Table))
+	} else {
+		parts = append(parts, keyHint("table", km.Table))
+	}
+	if m.hasProjects() {
+		parts = append(parts, keyHint("group", km.Group))
+	}
+	if m.history != nil {
+		parts = append(parts, keyHint("history", km.History))
+	}
+	parts = append(parts, keyHint("ports", km.Topology))
+	if m.disk != nil {
+		parts = append(parts, keyHint("disk", km.Disk))
+	}
+	if m.actions != nil {
+		parts = append(parts, joinHints("  ", keyHint("restart", km.Restart), keyHint("start/stop", km.StartStop), keyHint("pause", km.Pause)))
+	}
+	parts = append(parts, open, joinHints("  ", keyHint(m.sortLabel(), km.SortPrev, km.SortNext), keyHint("invert", km.SortInvert)), keyHint("keys", km.Help))
+	selectHint := hints(parts...)
+	if m.filter != nil {
+		selectHint += sep + lipgloss.NewStyle().Foreground(lipgloss.Color(colorLogo)).Render("filter: "+m.filter.String()) +
+			navStyle.Render(" ("+joinHints(", ", fmt.Sprintf("%d/%d", len(m.visible), len(m.items)), keyHint("clears", km.Back))+")")
+	}
+	if m.notice != "" {
+		selectHint += sep + lipgloss.NewStyle().Foreground(lipgloss.Color(colorInfo)).Render(m.notice)
+	}
 
-	leftNav := navStyle.Render("h/← prev")
-	rightNav := navStyle.Render("l/→ next")
+	if m.totalPages() <= 1 {
+		return lipgloss.NewStyle().
+			Width(m.termSize.Width).
+			Render(lipgloss.JoinHorizontal(lipgloss.Top, selectHint, sep, quit))
+	}
+
+	leftNav := navStyle.Render(km.PrevPage.Help().Key + " prev")
+	rightNav := navStyle.Render(km.NextPage.Help().Key + " next")
 
 	pageInfo := fmt.Sprintf(" %d / %d ", m.page+1, m.totalPages())
 	page := lipgloss.NewStyle().
@@ -92,7 +246,7 @@ func (m *UiModel) renderFooter() string {
 		Bold(true).
 		Render(pageInfo)
 
-	navBlock := lipgloss.JoinHorizontal(lipgloss.Center, leftNav, sep, page, sep, rightNav)
+	navBlock := lipgloss.JoinHorizontal(lipgloss.Center, leftNav, sep, page, sep, rightNav, sep, selectHint)
 
 	available := m.termSize.Width -
 		lipgloss.Width(navBlock) -
@@ -112,3 +266,14 @@ func (m *UiModel) renderFooter() string {
 		Width(m.termSize.Width).
 		Render(footer)
 }
+
+// joinHints joins the non-empty hints, which are empty for unbound keys.
+func joinHints(sep string, hs ...string) string {
+	var out []string
+	for _, h := range hs {
+		if h != "" {
+			out = append(out, h)
+		}
+	}
+	return strings.Join(out, sep)
+}
@@ -0,0 +1,201 @@
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+let palette = null;
+let snapshot = { containers: [] };
+let token = new URLSearchParams(location.search).get("token") || localStorage.getItem("docky-token") || "";
+
+function esc(s) {
+  return String(s ?? "").replace(/[&<>"']/g, (c) => ({ "&": "&amp;", "<": "&lt;", ">": "&gt;", '"': "&quot;", "'": "&#39;" })[c]);
+}
+
+function api(path, opts = {}) {
+  opts.headers = Object.assign({}, opts.headers, token ? { Authorization: "Bearer " + token } : {});
+  return fetch(path, opts);
+}
+
+async function authenticate() {
+  for (;;) {
+    const res = await api("/api/containers");
+    if (res.status !== 401) {
+      if (token) localStorage.setItem("docky-token", token);
+      return res.json();
+    }
+    token = prompt("docky-go API token") || "";
+    if (!token) throw new Error("no token");
+  }
+}
+
+function connect() {
+  const es = new EventSource("/api/stream" + (token ? "?token=" + encodeURIComponent(token) : ""));
+  es.addEventListener("containers", (e) => {
+    snapshot = JSON.parse(e.data);
+    $("status").textContent = "updated " + new Date(snapshot.generated_at).toLocaleTimeString();
+    render();
+  });
+  es.onerror = () => { $("status").textContent = "reconnecting…"; };
+}
+
+function look(type) {
+  return palette.types[type] || palette.generic;
+}
+
+function stateBadge(c) {
+  const s = palette.states[c.state] || { color: "#343A40", icon: "⭘", text: c.state.toUpperCase() };
+  let out = `<span class="badge" style="background:${s.color}">${s.icon} ${esc(s.text)}</span>`;
+  const h = palette.health[c.health];
+  if (h) out += `<span class="badge" style="background:${h.color}">${h.icon} ${esc(h.text)}</span>`;
+  if (c.restart_storm) out += `<span class="badge" style="background:${palette.alert}">↻ STORM</span>`;
+  if (c.oom_killed) out += `<span class="badge" style="background:${palette.alert}">OOM</span>`;
+  return out;
+}
+
+// actionsFor mirrors the TUI keys: r restarts, s toggles start/stop and p
+// toggles pause.
+function actionsFor(c) {
+  switch (c.state) {
+    case "paused": return ["unpause"];
+    case "running": case "unhealthy": case "restarting": return ["restart", "stop", "pause"];
+    default: return ["start"];
+  }
+}
+
+// detailText formats a typed detail field the way the TUI does.
+function detailText(d) {
+  switch (d.kind) {
+    case "bool": return d.value ? "yes" : "no";
+    case "percent": return d.value.toFixed(1) + "%";
+    case "bytes": {
+      let v = d.value, i = -1;
+      if (v < 1000) return v + "B";
+      while (v >= 1000 && i < 5) { v /= 1000; i++; }
+      return v.toFixed(1) + "kMGTPE"[i] + "B";
+    }
+    case "duration": {
+      const s = Math.round(d.value), h = Math.floor(s / 3600), m = Math.floor(s % 3600 / 60);
+      return (h ? h + "h" : "") + (h || m ? m + "m" : "") + (s % 60) + "s";
+    }
+    case "int": return d.unit ? `${d.value} ${d.unit}` : String(d.value);
+    default: return String(d.value);
+  }
+}
+
+function matches(c, q, state) {
+  if (state && c.state !== state) return false;
+  if (!q) return true;
+  return [c.name, c.image, c.type, c.compose_project, c.id].some((v) => (v || "").toLowerCase().includes(q));
+}
+
+// card renders the parts of a container's card that patchCard updates
+// separately, so a refresh leaves the buttons alone unless they change.
+function card(c) {
+  const a = look(c.type);
+  const mem = c.memory_limit_mb > 0 ? `${c.memory_mb}/${c.memory_limit_mb}MB` : `${c.memory_mb}MB`;
+  const details = (c.details || [])
+    .map((d) => `<div class="row"><b>${esc(d.name)}:</b> <span>${esc(detailText(d))}</span></div>`).join("");
+  return {
+    className: "card" + (c.alerts ? " alerting" : ""),
+    color: a.color,
+    body: `<h2 style="color:${a.color}">${a.icon} ${esc(c.name)}</h2>
+  <div>${stateBadge(c)}</div>
+  <div class="stats">CPU: ${c.cpu_percent.toFixed(1)}%  MEM: ${mem}  PIDs: ${c.pids}</div>
+  ${details}
+  ${/^https?:\/\//i.test(c.url || "") ? `<div class="row"><b>URL:</b> <a href="${esc(c.url)}" target="_blank" rel="noopener">${esc(c.url)}</a></div>` : ""}
+  <div class="row"><b>Image:</b> <span>${esc(c.image)}</span></div>
+  <div class="row"><b>ID:</b> <span>${esc(c.id.slice(0, 12))}</span></div>`,
+    actions: actionsFor(c).map((act) => `<button data-id="${esc(c.id)}" data-action="${act}">${act}</button>`).join(""),
+  };
+}
+
+// setHTML only touches el when html changed, so hovered and focused
+// elements survive refreshes that don't affect them.
+function setHTML(el, html) {
+  if (el.rendered !== html) {
+    el.innerHTML = html;
+    el.rendered = html;
+  }
+}
+
+function patchCard(el, c) {
+  const p = card(c);
+  el.className = p.className;
+  el.style.borderColor = p.color;
+  setHTML(el.firstElementChild, p.body);
+  setHTML(el.lastElementChild, p.actions);
+}
+
+// renderGrid updates the cards in place by container ID instead of
+// replacing the grid, which would drop clicks on buttons being pressed.
+function renderGrid(shown) {
+  const grid = $("grid");
+  if (!shown.length) {
+    setHTML(grid, `<p class="dim">No containers match.</p>`);
+    return;
+  }
+  if (grid.rendered) {
+    grid.innerHTML = "";
+    grid.rendered = undefined;
+  }
+  const old = new Map([...grid.children].map((el) => [el.dataset.id, el]));
+  let prev = null;
+  for (const c of shown) {
+    let el = old.get(c.id);
+    old.delete(c.id);
+    if (!el) {
+      el = document.createElement("section");
+      el.dataset.id = c.id;
+      el.innerHTML = `<div></div><div class="actions"></div>`;
+    }
+    patchCard(el, c);
+    const at = prev ? prev.nextElementSibling : grid.firstElementChild;
+    if (el !== at) grid.insertBefore(el, at);
+    prev = el;
+  }
+  for (const el of old.values()) el.remove();
+}
+
+function render() {
+  $("host").textContent = snapshot.host ? "@ " + snapshot.host : "";
+  const q = $("filter").value.trim().toLowerCase();
+  const state = $("state").value;
+  renderGrid(snapshot.containers.filter((c) => matches(c, q, state)));
+
+  const firing = snapshot.containers.flatMap((c) => (c.alerts || []).map((a) => ({ c, a })));
+  $("alerts").hidden = firing.length === 0;
+  $("alerts").innerHTML = firing.map(({ c, a }) =>
+    `<div>[${esc(a.severity)}] ${esc(c.name)}: ${esc(a.rule)} (${esc(a.value)})</div>`).join("");
+}
+
+function notice(msg) {
+  $("notice").textContent = msg;
+  $("notice").hidden = false;
+  clearTimeout(notice.timer);
+  notice.timer = setTimeout(() => { $("notice").hidden = true; }, 4000);
+}
+
+$("grid").addEventListener("click", async (e) => {
+  const b = e.target.closest("button[data-action]");
+  if (!b) return;
+  b.disabled = true;
+  const res = await api(`/api/containers/${encodeURIComponent(b.dataset.id)}/${b.dataset.action}`, { method: "POST" });
+  const body = await res.json().catch(() => ({}));
+  notice(res.ok ? `${b.dataset.action} ${body.name}: ok` : `${b.dataset.action} failed: ${body.error || res.status}`);
+  b.disabled = false;
+});
+$("filter").addEventListener("input", render);
+$("state").addEventListener("change", render);
+
+(async () => {
+  palette = await (await fetch("palette.json")).json();
+  for (const s of Object.keys(palette.states)) {
+    $("state").insertAdjacentHTML("beforeend", `<option value="${s}">${s}</option>`);
+  }
+  try {
+    snapshot = await authenticate();
+  } catch {
+    $("status").textContent = "not authorized";
+    return;
+  }
+  render();
+  connect();
+})();
@@ -0,0 +1,35 @@
+// Package web serves the browser dashboard. The page itself is static and
+// embedded; live data comes from the api package's SSE stream and actions go
+// through its POST endpoints, so the browser sees exactly what the TUI sees.
+package web
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed static
+var static embed.FS
+
+// Handler serves the dashboard at / and the colour/icon palette at
+// /palette.json. Neither carries container data, so they are not behind the
+// API token; the page asks for it and passes it to the API.
+func Handler(palette any) http.Handler {
+	root, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	paletteJSON, err := json.Marshal(palette)
+	if err != nil {
+		panic(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(root))
+	mux.HandleFunc("GET /palette.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(paletteJSON)
+	})
+	return mux
+}
@@ -0,0 +1,49 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerServesDashboard(t *testing.T) {
+	srv := httptest.NewServer(Handler(map[string]string{"accent": "#FDF500"}))
+	defer srv.Close()
+
+	for path, want := range map[string]string{
+		"/":          "<title>docky-go</title>",
+		"/app.js":    "/api/stream",
+		"/style.css": "#grid",
+	} {
+		res, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK || !strings.Contains(string(body), want) {
+			t.Errorf("GET %s: status %d, missing %q", path, res.StatusCode, want)
+		}
+	}
+}
+
+func TestHandlerServesPalette(t *testing.T) {
+	srv := httptest.NewServer(Handler(map[string]string{"accent": "#FDF500"}))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/palette.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var got map[string]string
+	if err := json.NewDecoder(res.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got["accent"] != "#FDF500" || res.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("palette = %v (%s)", got, res.Header.Get("Content-Type"))
+	}
+}
@@ -2,28 +2,302 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/wosiu6/docky-go/internal/alert"
+	"github.com/wosiu6/docky-go/internal/api"
+	"github.com/wosiu6/docky-go/internal/config"
 	"github.com/wosiu6/docky-go/internal/docker"
+	"github.com/wosiu6/docky-go/internal/exporter"
 	"github.com/wosiu6/docky-go/internal/fetcher"
+	"github.com/wosiu6/docky-go/internal/filter"
+	"github.com/wosiu6/docky-go/internal/history"
 	"github.com/wosiu6/docky-go/internal/log"
 	"github.com/wosiu6/docky-go/internal/orchestrator"
+	"github.com/wosiu6/docky-go/internal/replay"
+	"github.com/wosiu6/docky-go/internal/snapshot"
 	"github.com/wosiu6/docky-go/internal/ui"
+	"github.com/wosiu6/docky-go/internal/web"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "snapshot":
+			os.Exit(runSnapshot(os.Args[2:]))
+		case "config":
+			os.Exit(runConfig(os.Args[2:]))
+		case "serve", "record", "replay":
+			os.Exit(run(os.Args[1], os.Args[2:]))
+		}
+	}
+	os.Exit(run("docky-go", os.Args[1:]))
+}
+
+// run starts the refresh loop with the TUI and any enabled consumers. The
+// serve command is the same loop with the REST/SSE API switched on; record
+// and replay swap the Docker client for a recording or a playback of one.
+func run(name string, args []string) int {
+	cfg, path, err := loadConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		return 2
+	}
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	commonFlags(fs, &cfg, path)
+	fs.StringVar(&cfg.Exporters.MetricsAddr, "metrics-addr", cfg.Exporters.MetricsAddr, "serve Prometheus metrics on this address, e.g. :9090")
+	fs.BoolVar(&cfg.Exporters.MetricsLive, "metrics-live", cfg.Exporters.MetricsLive, "fetch fresh data on every scrape instead of serving the last refresh")
+	fs.Func("push", "comma-separated push targets: influx://, influxs://, otlp://, otlps://, graphite:// or statsd:// URLs (env DOCKY_PUSH)", func(s string) error {
+		cfg.Exporters.Push = config.SplitList(s)
+		return nil
+	})
+	fs.DurationVar(&cfg.Refresh.Interval, "interval", cfg.Refresh.Interval, "refresh interval (env DOCKY_REFRESH_INTERVAL)")
+	fs.StringVar(&cfg.Sort.Key, "sort", cfg.Sort.Key, "initial sort key: "+strings.Join(ui.SortKeys(), ", ")+" (env DOCKY_SORT)")
+	fs.BoolVar(&cfg.Sort.Reverse, "sort-reverse", cfg.Sort.Reverse, "invert the initial sort")
+	fs.BoolVar(&cfg.Sort.Group, "group", cfg.Sort.Group, "group containers by compose project")
+	fs.StringVar(&cfg.View, "view", cfg.View, "initial view: cards or table (env DOCKY_VIEW)")
+	fs.StringVar(&cfg.Theme, "theme", cfg.Theme, "colour theme: "+strings.Join(ui.Themes(), ", ")+" or one from the config (env DOCKY_THEME)")
+	fs.StringVar(&cfg.Alerts.Webhook, "alert-webhook", cfg.Alerts.Webhook, "POST alert notifications to this URL (env DOCKY_ALERT_WEBHOOK)")
+	fs.StringVar(&cfg.Alerts.Command, "alert-command", cfg.Alerts.Command, "run this command for alert notifications (env DOCKY_ALERT_COMMAND)")
+	fs.BoolVar(&cfg.Alerts.Bell, "alert-bell", cfg.Alerts.Bell, "ring the terminal bell when an alert fires (env DOCKY_ALERT_BELL)")
+	headless := fs.Bool("headless", false, "run without the TUI, e.g. as a metrics exporter")
+	keepHistory := fs.Bool("history", false, "record CPU and memory samples for the history screen")
+	historyDir := fs.String("history-dir", history.DefaultDir(), "directory for history samples")
+	historyRetention := fs.Duration("history-retention", history.DefaultOptions().Retention, "how long history samples are kept")
+	var listen, token *string
+	var dashboard *bool
+	if name == "serve" {
+		listen = fs.String("listen", "127.0.0.1:8080", "address for the REST API and web dashboard")
+		dashboard = fs.Bool("web", true, "serve the web dashboard at / next to the API")
+		token = fs.String("token", os.Getenv("DOCKY_API_TOKEN"), "API bearer token; generated when empty (env DOCKY_API_TOKEN)")
+	}
+	var speed *float64
+	var loop *bool
+	if name == "replay" {
+		speed = fs.Float64("speed", 1, "playback speed; 2 plays twice as fast")
+		loop = fs.Bool("loop", false, "start over after the last frame")
+	}
+	fs.Parse(args)
+	if !applyConfig(cfg) {
+		return 2
+	}
+	only, _ := filter.Parse(cfg.Filter)
+	if only.Empty() {
+		only = nil
+	} else {
+		only.Host = docker.HostName()
+	}
+	if (name == "record" || name == "replay") && fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: docky-go %s [flags] FILE.jsonl\n", name)
+		return 2
+	}
 
 	logger := log.New()
 
-	dockerClient, err := docker.NewClient()
+	var dockerClient docker.DockerClient
+	switch name {
+	case "replay":
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			logger.Error("cannot open recording", "error", err)
+			return 1
+		}
+		player, err := replay.NewPlayer(f, replay.WithSpeed(*speed), replay.WithLoop(*loop))
+		f.Close()
+		if err != nil {
+			logger.Error("cannot load recording", "error", err)
+			return 1
+		}
+		dockerClient = player
+	case "record":
+		c, ok := connect(logger, cfg.Docker)
+		if !ok {
+			return 1
+		}
+		f, err := os.Create(fs.Arg(0))
+		if err != nil {
+			logger.Error("cannot create recording", "error", err)
+			return 1
+		}
+		defer f.Close()
+		rec, err := replay.NewRecorder(c, f, docker.HostName())
+		if err != nil {
+			logger.Error("cannot write recording", "error", err)
+			return 1
+		}
+		defer func() {
+			if err := rec.Err(); err != nil {
+				logger.Error("recording incomplete", "error", err)
+			}
+		}()
+		dockerClient = rec
+	default:
+		c, ok := connect(logger, cfg.Docker)
+		if !ok {
+			return 1
+		}
+		dockerClient = c
+	}
+	actions, _ := dockerClient.(docker.ActionClient)
+
+	dockerService := docker.NewService(dockerClient)
+	containerFetcher := fetcher.NewWithServiceConfig(dockerService, dockerClient, fetcherConfig(cfg))
+	source := masker(cfg).Source(fetcher.NewServiceAdapter(containerFetcher))
+
+	var opts []orchestrator.Option
+	var store *history.Store
+	if *keepHistory {
+		historyOpts := history.DefaultOptions()
+		historyOpts.Retention = *historyRetention
+		var err error
+		if store, err = history.Open(*historyDir, historyOpts, logger); err != nil {
+			logger.Error("cannot open history", "error", err)
+			return 1
+		}
+		defer store.Close()
+		opts = append(opts, orchestrator.WithConsumer(store))
+	}
+
+	var uiApp orchestrator.UiApp
+	var uiModel *ui.UiModel
+	if !*headless {
+		uiModel = ui.New(containerFetcher)
+		uiModel.SetActions(actions)
+		uiModel.SetNetworks(containerFetcher)
+		if _, ok := dockerClient.(docker.DiskClient); ok {
+			uiModel.SetDisk(containerFetcher)
+		}
+		if pruner, ok := dockerClient.(docker.PruneClient); ok {
+			uiModel.SetPrune(pruner)
+		}
+		uiModel.SetHost(docker.HostName())
+		uiModel.SetFilter(only)
+		uiModel.SetGrouped(cfg.Sort.Group)
+		uiModel.SetTableView(cfg.View == config.ViewTable)
+		uiModel.SetSort(cfg.Sort.Key, cfg.Sort.Reverse)
+		uiModel.SetKeyBindings(cfg.Keybindings)
+		if store != nil {
+			uiModel.SetHistory(store)
+		}
+		uiApp = ui.NewAdapter(uiModel)
+	}
+
+	var notifiers []alert.Notifier
+	if cfg.Alerts.Webhook != "" {
+		notifiers = append(notifiers, &alert.WebhookNotifier{URL: cfg.Alerts.Webhook})
+	}
+	if cfg.Alerts.Command != "" {
+		notifiers = append(notifiers, &alert.CommandNotifier{Command: strings.Fields(cfg.Alerts.Command)})
+	}
+	if cfg.Alerts.Bell {
+		notifiers = append(notifiers, &alert.BellNotifier{})
+	}
+	alerts, err := alert.NewEngine(alertRules(cfg), logger, notifiers...)
+	if err != nil {
+		logger.Error("invalid alert rules", "error", err)
+		return 1
+	}
+	// deferred before the exporters and servers, so it runs after them and
+	// delivers what the last refresh raised
+	defer alerts.Close()
+
+	opts = append(opts, orchestrator.WithAlerts(alerts))
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if cfg.Exporters.MetricsAddr != "" {
+		var prom *exporter.Prometheus
+		if cfg.Exporters.MetricsLive {
+			prom = exporter.NewPrometheus(only.Source(source), docker.HostName())
+		} else {
+			prom = exporter.NewPrometheus(nil, docker.HostName())
+			prom.MaxAge = 3 * cfg.Refresh.Interval
+			opts = append(opts, orchestrator.WithConsumer(only.Consumer(prom)))
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", prom)
+		defer serveHTTP(logger, "metrics", cfg.Exporters.MetricsAddr, mux)()
+	}
+
+	for _, spec := range cfg.Exporters.Push {
+		sink, _ := exporter.ParseSink(spec)
+		switch sink := sink.(type) {
+		case *exporter.InfluxSink:
+			sink.Token = cfg.Exporters.InfluxToken
+		case *exporter.OTLPSink:
+			sink.Headers = cfg.Exporters.OTLPHeaders
+		}
+		pusher := exporter.NewPusher(sink, docker.HostName(), exporter.DefaultPushOptions(), logger)
+		defer pusher.Close()
+		opts = append(opts, orchestrator.WithConsumer(only.Consumer(pusher)))
+	}
+
+	if listen != nil {
+		if *token == "" {
+			*token = randomToken()
+			if uiModel != nil {
+				uiModel.SetAPIToken(*token)
+			} else {
+				fmt.Fprintf(os.Stderr, "docky-go API token: %s\n", *token)
+			}
+		}
+		server := api.New(*token, docker.HostName(), actions, logger)
+		opts = append(opts, orchestrator.WithConsumer(server))
+		var h http.Handler = server
+		if *dashboard {
+			mux := http.NewServeMux()
+			mux.Handle("/api/", server)
+			mux.Handle("/", web.Handler(ui.Palette()))
+			h = mux
+		}
+		defer serveHTTP(logger, "api", *listen, h)()
+	}
+
+	orchestrator := orchestrator.New(source, uiApp, logger, cfg.Refresh.Interval, opts...)
+	if err := orchestrator.Start(ctx); err != nil && err != context.Canceled {
+		logger.Error("application error", "error", err)
+		return 1
+	}
+	return 0
+}
+
+// serveHTTP starts a server in the background and returns its shutdown func.
+func serveHTTP(logger log.Logger, name, addr string, h http.Handler) func() {
+	srv := &http.Server{Addr: addr, Handler: h, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error(name+" server failed", "error", err)
+		}
+	}()
+	return func() { srv.Close() }
+}
+
+func randomToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func connect(logger log.Logger, cfg config.Docker) (docker.DockerClient, bool) {
+	dockerClient, err := docker.NewClientWithOptions(docker.WithTimeout(cfg.Timeout))
 	if err != nil {
 		logger.Error("failed to create docker client", "error", err)
-		os.Exit(1)
+		return nil, false
 	}
 
-	pingCtx, cancelPing := context.WithTimeout(context.Background(), 2*time.Second)
+	pingCtx, cancelPing := context.WithTimeout(context.Background(), cfg.PingTimeout)
 	defer cancelPing()
 	if err := dockerClient.Ping(pingCtx); err != nil {
 		if runtime.GOOS == "windows" {
@@ -31,21 +305,44 @@ func main() {
 		} else {
 			logger.Error("Cannot reach Docker. Ensure the Docker daemon is running and /var/run/docker.sock is accessible. Ensure you have permission to access the Docker socket/are a part of the docker group.", "error", err)
 		}
-		os.Exit(1)
+		return nil, false
 	}
+	return dockerClient, true
+}
 
-	dockerService := docker.NewService(dockerClient)
-	containerFetcher := fetcher.NewWithService(dockerService, dockerClient)
-	serviceAdapter := fetcher.NewServiceAdapter(containerFetcher)
-
-	uiModel := ui.New(containerFetcher)
-	uiAdapter := ui.NewAdapter(uiModel)
+func runSnapshot(args []string) int {
+	cfg, path, err := loadConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		return 2
+	}
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	commonFlags(fs, &cfg, path)
+	format := fs.String("format", "table", "output format: "+strings.Join(snapshot.Formats, ", "))
+	sample := fs.Duration("sample", 500*time.Millisecond, "delay between the two reads used to compute CPU %; 0 skips the second read")
+	fs.Parse(args)
+	if !applyConfig(cfg) {
+		return 2
+	}
+	only, _ := filter.Parse(cfg.Filter)
+	only.Host = docker.HostName()
 
-	orchestrator := orchestrator.New(serviceAdapter, uiAdapter, logger, time.Second)
-	ctx, cancel := context.WithCancel(context.Background())
+	logger := log.New()
+	dockerClient, ok := connect(logger, cfg.Docker)
+	if !ok {
+		return 1
+	}
+	src := masker(cfg).Source(fetcher.NewServiceAdapter(fetcher.NewWithServiceConfig(docker.NewService(dockerClient), dockerClient, fetcherConfig(cfg))))
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
-	if err := orchestrator.Start(ctx); err != nil && err != context.Canceled {
-		logger.Error("application error", "error", err)
-		os.Exit(1)
+	snap, err := snapshot.Take(ctx, only.Source(src), only.Host, *sample)
+	if err != nil {
+		logger.Error("snapshot failed", "error", err)
+		return 1
+	}
+	if err := snapshot.Write(os.Stdout, snap, *format); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
 	}
+	return 0
 }